@@ -0,0 +1,87 @@
+// Package arrowuuid provides helpers for storing github.com/rsms/go-uuid UUIDs in
+// Apache Arrow arrays and Parquet files, github.com/apache/arrow-go.
+//
+// UUIDs are stored as FixedSizeBinary(16) arrays in Arrow and as FIXED_LEN_BYTE_ARRAY(16)
+// columns annotated with the UUID logical type in Parquet. Since both hold the 16 bytes
+// of each UUID back-to-back, conversions are a single copy or no copy at all.
+package arrowuuid
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/rsms/go-uuid"
+)
+
+// DataType is the Arrow data type of UUID arrays, FixedSizeBinary(16)
+var DataType = &arrow.FixedSizeBinaryType{ByteWidth: 16}
+
+// NewArray returns a FixedSizeBinary(16) array of ids without copying them.
+// The array references the memory of ids, which must not be modified while the array
+// is in use.
+func NewArray(ids []uuid.UUID) *array.FixedSizeBinary {
+	var b []byte
+	if len(ids) > 0 {
+		b = unsafe.Slice(&ids[0][0], len(ids)*16)
+	}
+	data := array.NewData(DataType, len(ids), []*memory.Buffer{nil, memory.NewBufferBytes(b)}, nil, 0, 0)
+	defer data.Release()
+	return array.NewFixedSizeBinaryData(data)
+}
+
+// NewExtensionArray returns an array of ids with the arrow.uuid canonical extension type,
+// which pqarrow writes to Parquet with the UUID logical type.
+// Like NewArray, the array references the memory of ids.
+func NewExtensionArray(ids []uuid.UUID) arrow.Array {
+	storage := NewArray(ids)
+	defer storage.Release()
+	return array.NewExtensionArrayWithStorage(extensions.NewUUIDType(), storage)
+}
+
+// UUIDs returns the UUIDs of arr. See AppendUUIDs.
+func UUIDs(arr arrow.Array) ([]uuid.UUID, error) {
+	return AppendUUIDs(make([]uuid.UUID, 0, arr.Len()), arr)
+}
+
+// AppendUUIDs appends the UUIDs of arr to dst. arr must be a FixedSizeBinary(16) array or
+// an extension array with such storage, like those produced by NewExtensionArray.
+// Null entries are appended as the zero UUID.
+func AppendUUIDs(dst []uuid.UUID, arr arrow.Array) ([]uuid.UUID, error) {
+	if ext, ok := arr.(array.ExtensionArray); ok {
+		arr = ext.Storage()
+	}
+	a, ok := arr.(*array.FixedSizeBinary)
+	if !ok || a.DataType().(*arrow.FixedSizeBinaryType).ByteWidth != 16 {
+		return dst, fmt.Errorf("uuid: cannot read UUIDs from Arrow array of type %s", arr.DataType())
+	}
+	n := a.Len()
+	if n == 0 {
+		return dst, nil
+	}
+	start := len(dst)
+	dst = append(dst, make([]uuid.UUID, n)...)
+	data := a.Data()
+	off := data.Offset() * 16
+	copy(unsafe.Slice(&dst[start][0], n*16), data.Buffers()[1].Bytes()[off:off+n*16])
+	if a.NullN() > 0 {
+		for i := 0; i < n; i++ {
+			if a.IsNull(i) {
+				dst[start+i] = uuid.UUID{}
+			}
+		}
+	}
+	return dst, nil
+}
+
+// ParquetNode returns a Parquet schema node for a column of UUIDs, a FIXED_LEN_BYTE_ARRAY(16)
+// with the UUID logical type. Pass -1 for fieldID to leave it unset.
+func ParquetNode(name string, repetition parquet.Repetition, fieldID int32) (*schema.PrimitiveNode, error) {
+	return schema.NewPrimitiveNodeLogical(name, repetition, schema.UUIDLogicalType{},
+		parquet.Types.FixedLenByteArray, 16, fieldID)
+}
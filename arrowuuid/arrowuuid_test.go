@@ -0,0 +1,124 @@
+package arrowuuid
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func genIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.MustGen()
+	}
+	return ids
+}
+
+func TestArray(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	ids := genIDs(10)
+	arr := NewArray(ids)
+	defer arr.Release()
+	assert.Eq("Len", arr.Len(), 10)
+	assert.Ok("DataType", arrow.TypeEqual(arr.DataType(), DataType))
+	for i, id := range ids {
+		assert.Eq("Value", arr.Value(i), id[:])
+	}
+
+	ids2, err := UUIDs(arr)
+	assert.NoErr("UUIDs", err)
+	assert.Ok("UUIDs", slices.Equal(ids2, ids))
+
+	// slices are read at their offset
+	s := array.NewSlice(arr, 3, 7)
+	defer s.Release()
+	ids2, err = AppendUUIDs(ids2[:1], s)
+	assert.NoErr("AppendUUIDs", err)
+	assert.Ok("AppendUUIDs", slices.Equal(ids2, append([]uuid.UUID{ids[0]}, ids[3:7]...)))
+
+	empty := NewArray(nil)
+	defer empty.Release()
+	ids2, err = UUIDs(empty)
+	assert.NoErr("UUIDs empty", err)
+	assert.Eq("UUIDs empty", len(ids2), 0)
+}
+
+func TestAppendUUIDsNulls(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+	b := array.NewFixedSizeBinaryBuilder(memory.DefaultAllocator, DataType)
+	defer b.Release()
+	b.Append(id[:])
+	b.AppendNull()
+	b.Append(id[:])
+	arr := b.NewArray()
+	defer arr.Release()
+	ids, err := UUIDs(arr)
+	assert.NoErr("UUIDs", err)
+	assert.Ok("UUIDs", slices.Equal(ids, []uuid.UUID{id, {}, id}))
+}
+
+func TestAppendUUIDsInvalid(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	b := array.NewFixedSizeBinaryBuilder(memory.DefaultAllocator, &arrow.FixedSizeBinaryType{ByteWidth: 8})
+	defer b.Release()
+	b.Append(make([]byte, 8))
+	arr := b.NewArray()
+	defer arr.Release()
+	_, err := UUIDs(arr)
+	assert.Err("byte width", "fixed_size_binary[8]", err)
+
+	sb := array.NewStringBuilder(memory.DefaultAllocator)
+	defer sb.Release()
+	sarr := sb.NewArray()
+	defer sarr.Release()
+	_, err = UUIDs(sarr)
+	assert.Err("string array", "utf8", err)
+}
+
+func TestParquet(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	ids := genIDs(5)
+	arr := NewExtensionArray(ids)
+	defer arr.Release()
+
+	sc := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arr.DataType()}}, nil)
+	rec := array.NewRecordBatch(sc, []arrow.Array{arr}, int64(len(ids)))
+	defer rec.Release()
+	var buf bytes.Buffer
+	w, err := pqarrow.NewFileWriter(sc, &buf, nil, pqarrow.DefaultWriterProps())
+	assert.NoErr("NewFileWriter", err)
+	assert.NoErr("Write", w.Write(rec))
+	assert.NoErr("Close", w.Close())
+
+	r, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	assert.NoErr("NewParquetReader", err)
+	col := r.MetaData().Schema.Column(0)
+	node, err := ParquetNode("id", parquet.Repetitions.Optional, -1)
+	assert.NoErr("ParquetNode", err)
+	assert.Eq("physical type", col.PhysicalType(), node.PhysicalType())
+	assert.Eq("type length", col.TypeLength(), node.TypeLength())
+	assert.Ok("logical type", col.LogicalType().Equals(schema.UUIDLogicalType{}))
+
+	fr, err := pqarrow.NewFileReader(r, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	assert.NoErr("NewFileReader", err)
+	tbl, err := fr.ReadTable(t.Context())
+	assert.NoErr("ReadTable", err)
+	defer tbl.Release()
+	var got []uuid.UUID
+	for _, chunk := range tbl.Column(0).Data().Chunks() {
+		got, err = AppendUUIDs(got, chunk)
+		assert.NoErr("AppendUUIDs", err)
+	}
+	assert.Ok("round trip", slices.Equal(got, ids))
+}
@@ -0,0 +1,128 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// String32Len is the length of the base32 string representation of a UUID,
+// i.e. as returned by UUID.String32()
+const String32Len = 26
+
+// crockfordCharacters is Douglas Crockford's base32 alphabet, which excludes the letters
+// I, L, O and U to avoid ambiguity. Like base62Characters it is in ASCII order.
+const crockfordCharacters = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordDecode maps a character to its value, or 0xff for invalid characters.
+// Lowercase letters are accepted as well as the commonly mistaken I and L (1) and O (0).
+var crockfordDecode = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xff
+	}
+	for i := 0; i < len(crockfordCharacters); i++ {
+		c := crockfordCharacters[i]
+		t[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			t[c+('a'-'A')] = byte(i)
+		}
+	}
+	t['I'], t['i'], t['L'], t['l'] = 1, 1, 1, 1
+	t['O'], t['o'] = 0, 0
+	return
+}()
+
+// String32 returns a case-insensitive base32 string representation of the UUID using
+// the Crockford alphabet. The returned string is always String32Len (26) characters long
+// and sorts in the same order as the "raw" UUID bytes.
+func (id UUID) String32() string {
+	var buf [String32Len]byte
+	id.EncodeString32(buf[:])
+	return string(buf[:])
+}
+
+// FromString32 decodes a base32 string representation of an UUID (i.e. from String32())
+func FromString32(encoded string) (UUID, error) {
+	var id UUID
+	err := id.DecodeString32([]byte(encoded))
+	return id, err
+}
+
+// EncodeString32 writes the Crockford base32 representation of the receiver to dst
+// which must be at least String32Len (26) bytes. Exactly String32Len bytes are written.
+func (id UUID) EncodeString32(dst []byte) {
+	_ = dst[String32Len-1] // bounds check
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := String32Len - 1; i >= 0; i-- {
+		dst[i] = crockfordCharacters[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+}
+
+// DecodeString32 sets the receiving UUID to the decoded value of src, which is expected to be
+// a string previously encoded using EncodeString32. Decoding is case-insensitive and the
+// letters I and L are read as 1 and O as 0.
+// An error is returned if src is not String32Len long or contains invalid characters.
+// The receiver is only modified when src is valid.
+func (id *UUID) DecodeString32(src []byte) error {
+	if len(src) != String32Len {
+		return fmt.Errorf("uuid: invalid base32 string length %d", len(src))
+	}
+	var hi, lo uint64
+	for i, c := range src {
+		v := crockfordDecode[c]
+		if v == 0xff {
+			return &InvalidCharError{Char: c, Offset: i}
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	// 26 characters encode 130 bits; the first character must only use the lower 3 bits
+	if crockfordDecode[src[0]] > 7 {
+		return fmt.Errorf("uuid: %q overflows 128 bits", src)
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return nil
+}
+
+// DNSLabelLen is the length of the DNS label representation of a UUID,
+// i.e. as returned by UUID.DNSLabel()
+const DNSLabelLen = 1 + String32Len
+
+// DNSLabel returns the UUID as a string which is valid as a DNS label and as the name of
+// resources with similar rules, like Kubernetes objects and S3 buckets: the letter 'u'
+// followed by String32 in lowercase, e.g. "u006423j0p977718v0bvegm0xvr".
+// The returned string is always DNSLabelLen (27) characters long and, like String32,
+// sorts in the same order as the UUIDs. Use FromDNSLabel to decode it.
+func (id UUID) DNSLabel() string {
+	var buf [DNSLabelLen]byte
+	buf[0] = 'u'
+	id.EncodeString32(buf[1:])
+	for i, c := range buf {
+		if c >= 'A' && c <= 'Z' {
+			buf[i] = c + ('a' - 'A')
+		}
+	}
+	return string(buf[:])
+}
+
+// FromDNSLabel decodes a DNS label representation of a UUID (i.e. from DNSLabel()).
+// Like DNS names, it is case-insensitive.
+func FromDNSLabel(s string) (UUID, error) {
+	var id UUID
+	if len(s) != DNSLabelLen {
+		return id, fmt.Errorf("uuid: invalid DNS label length %d", len(s))
+	}
+	if s[0] != 'u' && s[0] != 'U' {
+		return id, &InvalidCharError{Char: s[0], Offset: 0}
+	}
+	if err := id.DecodeString32([]byte(s[1:])); err != nil {
+		if e, ok := err.(*InvalidCharError); ok {
+			e.Offset++
+		}
+		return UUID{}, err
+	}
+	return id, nil
+}
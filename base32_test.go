@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestBase32(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	assert.Eq("Min", Min.String32(), "00000000000000000000000000")
+	assert.Eq("Max", Max.String32(), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	smallId := UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0xff}
+	assert.Eq("smallId", smallId.String32(), "000000000000000000000000FZ")
+
+	for _, id := range []UUID{Min, Max, smallId, MustGen(), MustGen()} {
+		s := id.String32()
+		assert.Eq("length", len(s), String32Len)
+		id2, err := FromString32(s)
+		assert.NoErr("FromString32(%q)", err, s)
+		assert.Eq("FromString32(String32())", id2, id)
+		id2, err = FromString32(strings.ToLower(s))
+		assert.NoErr("FromString32 lowercase", err)
+		assert.Eq("FromString32 lowercase", id2, id)
+	}
+
+	// ambiguous characters
+	id, err := FromString32("0000000000000000000000000I")
+	assert.NoErr("I", err)
+	assert.Eq("I is read as 1", id[15], byte(1))
+	id, err = FromString32("000000000000000000000000lO")
+	assert.NoErr("lO", err)
+	assert.Eq("l is read as 1 and O as 0", id[15], byte(32))
+
+	_, err = FromString32("0000000000000000000000000U")
+	assert.Err("U", `invalid character 'U' at offset 25`, err)
+	_, err = FromString32("000")
+	assert.Err("short", "invalid base32 string length 3", err)
+	_, err = FromString32("80000000000000000000000000")
+	assert.Err("overflow", "overflows 128 bits", err)
+
+	// sort order is preserved
+	ids := []UUID{Max, MustGen(), Min, smallId, MustGen(), New(1600000000+5, 0, []byte{0xff})}
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String32()
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	sort.Strings(strs)
+	for i, id := range ids {
+		assert.Eq("sort order", strs[i], id.String32())
+	}
+}
+
+func TestDNSLabel(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustFromString("MOpuNo4XU2HUSbBwf29A")
+	assert.Eq("DNSLabel", id.DNSLabel(), "u006423j0p977718v0bvegm0xvr")
+	label := regexp.MustCompile(`^[a-z][a-z0-9]{0,62}$`)
+	for _, id := range []UUID{id, Min, Max, MustGen()} {
+		s := id.DNSLabel()
+		assert.Eq("length", len(s), DNSLabelLen)
+		assert.Ok("valid DNS label "+s, label.MatchString(s))
+		id2, err := FromDNSLabel(s)
+		assert.NoErr("FromDNSLabel", err)
+		assert.Eq("FromDNSLabel", id2, id)
+		id2, err = FromDNSLabel(strings.ToUpper(s))
+		assert.NoErr("FromDNSLabel uppercase", err)
+		assert.Eq("FromDNSLabel uppercase", id2, id)
+	}
+
+	a, b := MustGen(), MustGen()
+	assert.Ok("sorts", bytes.Compare(a[:], b[:]) < 0 == (a.DNSLabel() < b.DNSLabel()))
+
+	s := id.DNSLabel()
+	_, err := FromDNSLabel(s[1:])
+	assert.Err("length", "invalid DNS label length 26", err)
+	var cerr *InvalidCharError
+	_, err = FromDNSLabel("x" + s[1:])
+	assert.Ok("prefix", errors.As(err, &cerr) && cerr.Offset == 0)
+	_, err = FromDNSLabel(s[:5] + "-" + s[6:])
+	assert.Ok("offset", errors.As(err, &cerr) && cerr.Offset == 5)
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// String58Len is the length of the base58 string representation of a UUID,
+// i.e. as returned by UUID.String58()
+const String58Len = 22
+
+// base58Characters is the Bitcoin base58 alphabet, which excludes 0, I, O and l to avoid
+// ambiguity. Like base62Characters it is in ASCII order.
+const base58Characters = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Decode maps a character to its value, or 0xff for invalid characters
+var base58Decode = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xff
+	}
+	for i := 0; i < len(base58Characters); i++ {
+		t[base58Characters[i]] = byte(i)
+	}
+	return
+}()
+
+// String58 returns a base58 string representation of the UUID using the Bitcoin alphabet.
+// The returned string is always String58Len (22) characters long, left-padded with "1"
+// (the zero digit), and sorts in the same order as the "raw" UUID bytes.
+//
+// Note that this is a plain 128-bit number in base 58 and differs from the "Base58Check"
+// encoding of Bitcoin addresses, which encodes each leading zero byte as a "1".
+func (id UUID) String58() string {
+	var buf [String58Len]byte
+	id.EncodeString58(buf[:])
+	return string(buf[:])
+}
+
+// FromString58 decodes a base58 string representation of an UUID (i.e. from String58())
+func FromString58(encoded string) (UUID, error) {
+	var id UUID
+	err := id.DecodeString58([]byte(encoded))
+	return id, err
+}
+
+// EncodeString58 writes the base58 representation of the receiver to dst which must be
+// at least String58Len (22) bytes. Exactly String58Len bytes are written.
+func (id UUID) EncodeString58(dst []byte) {
+	_ = dst[String58Len-1] // bounds check
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := String58Len - 1; i >= 0; i-- {
+		var r uint64
+		hi, r = hi/58, hi%58
+		lo, r = bits.Div64(r, lo, 58)
+		dst[i] = base58Characters[r]
+	}
+}
+
+// DecodeString58 sets the receiving UUID to the decoded value of src, which is expected to be
+// a string previously encoded using EncodeString58.
+// An error is returned if src is not String58Len long, contains invalid characters or
+// encodes a value larger than Max. The receiver is only modified when src is valid.
+func (id *UUID) DecodeString58(src []byte) error {
+	if len(src) != String58Len {
+		return fmt.Errorf("uuid: invalid base58 string length %d", len(src))
+	}
+	var hi, lo uint64
+	for i, c := range src {
+		v := base58Decode[c]
+		if v == 0xff {
+			return &InvalidCharError{Char: c, Offset: i}
+		}
+		// (hi, lo) = (hi, lo) * 58 + v
+		carry, lo1 := bits.Mul64(lo, 58)
+		ov, hi1 := bits.Mul64(hi, 58)
+		hi1, c1 := bits.Add64(hi1, carry, 0)
+		lo1, c2 := bits.Add64(lo1, uint64(v), 0)
+		hi1, c3 := bits.Add64(hi1, 0, c2)
+		if ov != 0 || c1 != 0 || c3 != 0 {
+			return fmt.Errorf("uuid: %q overflows 128 bits", src)
+		}
+		hi, lo = hi1, lo1
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return nil
+}
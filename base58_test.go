@@ -0,0 +1,47 @@
+package uuid
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestBase58(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	assert.Eq("Min", Min.String58(), "1111111111111111111111")
+	assert.Eq("Max", Max.String58(), "YcVfxkQb6JRzqk5kF2tNLv")
+	smallId := UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 58}
+	assert.Eq("smallId", smallId.String58(), "1111111111111111111121")
+
+	for _, id := range []UUID{Min, Max, smallId, MustGen(), MustGen()} {
+		s := id.String58()
+		assert.Eq("length", len(s), String58Len)
+		id2, err := FromString58(s)
+		assert.NoErr("FromString58(%q)", err, s)
+		assert.Eq("FromString58(String58())", id2, id)
+	}
+
+	_, err := FromString58("111111111111111111111O")
+	assert.Err("O", `invalid character 'O' at offset 21`, err)
+	_, err = FromString58("111")
+	assert.Err("short", "invalid base58 string length 3", err)
+	_, err = FromString58("YcVfxkQb6JRzqk5kF2tNLw") // Max+1
+	assert.Err("Max+1", "overflows 128 bits", err)
+	_, err = FromString58("zzzzzzzzzzzzzzzzzzzzzz")
+	assert.Err("overflow", "overflows 128 bits", err)
+
+	// sort order is preserved
+	ids := []UUID{Max, MustGen(), Min, smallId, MustGen(), New(1600000000+5, 0, []byte{0xff})}
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String58()
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	sort.Strings(strs)
+	for i, id := range ids {
+		assert.Eq("sort order", strs[i], id.String58())
+	}
+}
@@ -0,0 +1,30 @@
+package uuid
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// ToBase64URL returns the UUID encoded as unpadded URL-safe base64 (RFC 4648 section 5),
+// which is always 22 characters long.
+//
+// Note that, unlike String(), the base64url alphabet isn't in ASCII order and so the
+// returned strings don't sort in the same order as the UUIDs.
+func (id UUID) ToBase64URL() string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// FromBase64URL decodes a UUID encoded as unpadded URL-safe base64 (i.e. from ToBase64URL())
+func FromBase64URL(s string) (UUID, error) {
+	var id UUID
+	if len(s) != 22 {
+		return id, fmt.Errorf("uuid: invalid base64url string length %d", len(s))
+	}
+	// Strict rejects non-zero trailing bits, so that each UUID has exactly one encoding.
+	// The decoder skips CR and LF, which leaves id short of 16 bytes.
+	n, err := base64.RawURLEncoding.Strict().Decode(id[:], []byte(s))
+	if err != nil || n != len(id) {
+		return UUID{}, fmt.Errorf("uuid: invalid base64url string %q", s)
+	}
+	return id, nil
+}
@@ -0,0 +1,34 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestBase64URL(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	assert.Eq("Min", Min.ToBase64URL(), "AAAAAAAAAAAAAAAAAAAAAA")
+	assert.Eq("Max", Max.ToBase64URL(), "_____________________w")
+	id := UUID{0x00, 0x31, 0x04, 0x39, 0x02, 0xc9, 0x39, 0xce, 0x14, 0x6c, 0x0b, 0xdb, 0xa1, 0x40, 0x77, 0xfb}
+	assert.Eq("id", id.ToBase64URL(), "ADEEOQLJOc4UbAvboUB3-w")
+
+	for _, id := range []UUID{Min, Max, id, MustGen()} {
+		s := id.ToBase64URL()
+		id2, err := FromBase64URL(s)
+		assert.NoErr("FromBase64URL(%q)", err, s)
+		assert.Eq("FromBase64URL(ToBase64URL())", id2, id)
+	}
+
+	_, err := FromBase64URL("ADEEOQLJOc4UbAvboUB3-w==")
+	assert.Err("padded", "invalid base64url string length 24", err)
+	_, err = FromBase64URL("ADEEOQLJOc4UbAvboUB3+w")
+	assert.Err("standard alphabet", "invalid base64url string", err)
+	_, err = FromBase64URL("_____________________x")
+	assert.Err("non-zero trailing bits", "invalid base64url string", err)
+	_, err = FromBase64URL("____________________\r\n")
+	assert.Err("CR LF", "invalid base64url string", err)
+	_, err = FromBase64URL("__________\n__________\n")
+	assert.Err("LF", "invalid base64url string", err)
+}
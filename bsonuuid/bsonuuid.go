@@ -0,0 +1,86 @@
+// Package bsonuuid provides a BSON codec for github.com/rsms/go-uuid UUIDs, for use with
+// the MongoDB Go driver (go.mongodb.org/mongo-driver).
+//
+// By default UUIDs are stored as BSON binary values of subtype 4 ("UUID") holding the 16
+// bytes of the UUID verbatim, which preserves their sort order in indexes.
+package bsonuuid
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/rsms/go-uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+// Codec is a bsoncodec.ValueCodec for uuid.UUID.
+// UUIDs are encoded as BSON binary values with the subtype Subtype.
+// When decoding, binary values of either subtype 4 (UUID) or 0 (generic) are accepted.
+type Codec struct {
+	Subtype byte
+}
+
+// DefaultCodec encodes UUIDs as BSON binary subtype 4 (UUID)
+var DefaultCodec = &Codec{Subtype: bsontype.BinaryUUID}
+
+// Register registers DefaultCodec for uuid.UUID with r
+func Register(r *bsoncodec.Registry) {
+	r.RegisterTypeEncoder(uuidType, DefaultCodec)
+	r.RegisterTypeDecoder(uuidType, DefaultCodec)
+}
+
+// NewRegistry returns a new registry with the default BSON codecs and DefaultCodec
+// registered for uuid.UUID. Use it with options.Client().SetRegistry to make a client
+// store UUIDs natively.
+func NewRegistry() *bsoncodec.Registry {
+	r := bson.NewRegistry()
+	Register(r)
+	return r
+}
+
+// EncodeValue implements the bsoncodec.ValueEncoder interface
+func (c *Codec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != uuidType {
+		return bsoncodec.ValueEncoderError{Name: "UUIDEncodeValue", Types: []reflect.Type{uuidType}, Received: val}
+	}
+	id := val.Interface().(uuid.UUID)
+	return vw.WriteBinaryWithSubtype(id[:], c.Subtype)
+}
+
+// DecodeValue implements the bsoncodec.ValueDecoder interface
+func (c *Codec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != uuidType {
+		return bsoncodec.ValueDecoderError{Name: "UUIDDecodeValue", Types: []reflect.Type{uuidType}, Received: val}
+	}
+	var id uuid.UUID
+	switch t := vr.Type(); t {
+	case bsontype.Binary:
+		data, subtype, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if subtype != bsontype.BinaryUUID && subtype != bsontype.BinaryGeneric && subtype != c.Subtype {
+			return fmt.Errorf("uuid: cannot decode BSON binary subtype %#x into a UUID", subtype)
+		}
+		if err := id.UnmarshalBinary(data); err != nil {
+			return err
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("uuid: cannot decode BSON %v into a UUID", t)
+	}
+	val.Set(reflect.ValueOf(id))
+	return nil
+}
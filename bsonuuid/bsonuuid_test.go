@@ -0,0 +1,70 @@
+package bsonuuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type record struct {
+	ID   uuid.UUID `bson:"_id"`
+	Name string    `bson:"name"`
+}
+
+func TestCodec(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	reg := NewRegistry()
+	id := uuid.MustGen()
+
+	data, err := bson.MarshalWithRegistry(reg, record{id, "a"})
+	assert.NoErr("Marshal", err)
+
+	// check the raw representation
+	raw := bson.Raw(data)
+	subtype, b := raw.Lookup("_id").Binary()
+	assert.Eq("subtype", subtype, bsontype.BinaryUUID)
+	assert.Eq("binary data", b, id[:])
+
+	var r record
+	assert.NoErr("Unmarshal", bson.UnmarshalWithRegistry(reg, data, &r))
+	assert.Eq("round trip", r, record{id, "a"})
+
+	// generic binary subtype is accepted when decoding
+	data, _ = bson.Marshal(bson.M{"_id": primitive.Binary{Subtype: 0, Data: id[:]}})
+	assert.NoErr("Unmarshal generic binary", bson.UnmarshalWithRegistry(reg, data, &r))
+	assert.Eq("Unmarshal generic binary", r.ID, id)
+
+	// null decodes as the zero UUID
+	data, _ = bson.Marshal(bson.M{"_id": nil})
+	assert.NoErr("Unmarshal null", bson.UnmarshalWithRegistry(reg, data, &r))
+	assert.Eq("Unmarshal null", r.ID, uuid.Min)
+
+	data, _ = bson.Marshal(bson.M{"_id": primitive.Binary{Subtype: 4, Data: id[:8]}})
+	assert.Err("wrong length", "invalid binary data length 8",
+		bson.UnmarshalWithRegistry(reg, data, &r))
+	data, _ = bson.Marshal(bson.M{"_id": primitive.Binary{Subtype: 0x80, Data: id[:]}})
+	assert.Err("wrong subtype", "subtype 0x80", bson.UnmarshalWithRegistry(reg, data, &r))
+	data, _ = bson.Marshal(bson.M{"_id": "abc"})
+	assert.Err("wrong type", "cannot decode BSON string", bson.UnmarshalWithRegistry(reg, data, &r))
+}
+
+func TestCustomSubtype(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	reg := bson.NewRegistry()
+	c := &Codec{Subtype: bsontype.BinaryGeneric}
+	reg.RegisterTypeEncoder(uuidType, c)
+	reg.RegisterTypeDecoder(uuidType, c)
+	id := uuid.MustGen()
+
+	data, err := bson.MarshalWithRegistry(reg, record{id, "a"})
+	assert.NoErr("Marshal", err)
+	subtype, _ := bson.Raw(data).Lookup("_id").Binary()
+	assert.Eq("subtype", subtype, bsontype.BinaryGeneric)
+	var r record
+	assert.NoErr("Unmarshal", bson.UnmarshalWithRegistry(reg, data, &r))
+	assert.Eq("round trip", r.ID, id)
+}
@@ -0,0 +1,16 @@
+module github.com/rsms/go-uuid/bsonuuid
+
+go 1.23.0
+
+require (
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+	go.mongodb.org/mongo-driver v1.17.4
+)
+
+require (
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
@@ -0,0 +1,72 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCheckChar is returned by ParseWithCheck when the check character doesn't match
+var ErrCheckChar = errors.New("uuid: check character mismatch")
+
+// StringWithCheck returns the UUID as a 23 characters long string for humans to read and
+// type, like support staff noting an ID from a phone call. It is StringPadded followed by
+// a check character computed with the Luhn mod N algorithm over the base62 alphabet,
+// which catches any single mistyped character and any swap of adjacent characters other
+// than "0" and "z".
+// Use ParseWithCheck to decode it.
+func (id UUID) StringWithCheck() string {
+	var buf [StringMaxLen + 1]byte
+	for i := range buf {
+		buf[i] = '0'
+	}
+	id.EncodeString(buf[:StringMaxLen])
+	buf[StringMaxLen] = base62Characters[(62-luhn62Sum(buf[:StringMaxLen], true))%62]
+	return string(buf[:])
+}
+
+// ParseWithCheck decodes a string returned by StringWithCheck, verifying its check
+// character. Leading zeros don't affect the check character and may be omitted.
+// ErrCheckChar is returned if the check character doesn't match, which usually means that
+// s was mistyped; other errors are the same as those of Parse.
+func ParseWithCheck(s string) (UUID, error) {
+	if len(s) < 2 || len(s) > StringMaxLen+1 {
+		return UUID{}, fmt.Errorf("uuid: invalid string length %d", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if !isBase62(s[i]) {
+			return UUID{}, &InvalidCharError{Char: s[i], Offset: i}
+		}
+	}
+	if luhn62Sum([]byte(s), false) != 0 {
+		return UUID{}, ErrCheckChar
+	}
+	return Parse(s[:len(s)-1])
+}
+
+// luhn62Sum returns the Luhn mod 62 sum of the base62 characters of s, modulo 62.
+// Characters are weighted 1 and 2 alternately from the right, starting with 2 if double is
+// true (when computing a check character) or 1 (when verifying one.)
+func luhn62Sum(s []byte, double bool) int {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		v := base62Value(s[i])
+		if double {
+			v *= 2
+			v = v/62 + v%62
+		}
+		sum += v
+		double = !double
+	}
+	return sum % 62
+}
+
+// base62Value returns the value of base62 character c
+func base62Value(c byte) int {
+	switch {
+	case c <= '9':
+		return int(c - '0')
+	case c <= 'Z':
+		return int(c-'A') + 10
+	}
+	return int(c-'a') + 36
+}
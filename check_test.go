@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestStringWithCheck(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	for _, id := range []UUID{{}, Max, MustFromString("14dkqb8qQuruFQRFEEaUf"), MustGen()} {
+		s := id.StringWithCheck()
+		assert.Eq("length", len(s), StringMaxLen+1)
+		assert.Eq("prefix", s[:StringMaxLen], id.StringPadded())
+		id2, err := ParseWithCheck(s)
+		assert.NoErr("ParseWithCheck "+s, err)
+		assert.Eq("ParseWithCheck "+s, id2, id)
+
+		// leading zeros may be omitted
+		short := id.String() + s[StringMaxLen:]
+		id2, err = ParseWithCheck(short)
+		assert.NoErr("ParseWithCheck "+short, err)
+		assert.Eq("ParseWithCheck "+short, id2, id)
+	}
+}
+
+func TestParseWithCheckTypos(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	s := MustGen().StringWithCheck()
+
+	// every single substituted character is detected
+	for i := 0; i < len(s); i++ {
+		for j := 0; j < len(base62Characters); j++ {
+			c := base62Characters[j]
+			if c == s[i] {
+				continue
+			}
+			typo := s[:i] + string(c) + s[i+1:]
+			_, err := ParseWithCheck(typo)
+			assert.Ok("detects substitution "+typo, err != nil)
+		}
+	}
+
+	// swaps of adjacent characters are detected, except for swapping "0" and "z"
+	for i := 0; i+1 < len(s); i++ {
+		a, b := base62Value(s[i]), base62Value(s[i+1])
+		if a == b || a+b == 61 && (a == 0 || b == 0) {
+			continue
+		}
+		typo := s[:i] + string(s[i+1]) + string(s[i]) + s[i+2:]
+		_, err := ParseWithCheck(typo)
+		assert.Ok("detects swap "+typo, errors.Is(err, ErrCheckChar))
+	}
+
+	_, err := ParseWithCheck("5")
+	assert.Err("too short", "invalid string length 1", err)
+	_, err = ParseWithCheck(s + "0")
+	assert.Err("too long", "invalid string length 24", err)
+	var cerr *InvalidCharError
+	_, err = ParseWithCheck(s[:5] + "-" + s[6:])
+	assert.Ok("InvalidCharError", errors.As(err, &cerr) && cerr.Offset == 5)
+}
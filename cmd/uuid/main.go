@@ -0,0 +1,87 @@
+// Command uuid generates and inspects UUIDs of github.com/rsms/go-uuid
+//
+// Usage:
+//
+//	uuid [gen] [-n count]   generate count UUIDs (default 1)
+//	uuid decode <uuid> ...  print the bytes and timestamp of each UUID
+//	uuid time <uuid> ...    print the timestamp of each UUID
+//
+// UUIDs may be given in any format accepted by uuid.ParseAny.
+// Options may appear before as well as after the command.
+// Timestamps are printed in UTC, or in the local time zone with -local.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rsms/go-uuid"
+)
+
+const usage = `usage: uuid [gen] [-n count]   generate count UUIDs (default 1)
+       uuid decode <uuid> ...  print the bytes and timestamp of each UUID
+       uuid time <uuid> ...    print the timestamp of each UUID
+<uuid> may be in any format accepted by uuid.ParseAny (base62, hex, GUID or URN)
+options (before or after the command):
+  -local                   print timestamps in the local time zone instead of UTC
+`
+
+func main() {
+	fs := flag.NewFlagSet("uuid", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	n := fs.Int("n", 1, "number of UUIDs to generate")
+	local := fs.Bool("local", false, "print times in the local time zone instead of UTC")
+
+	// parse options preceding the command, then the ones following it
+	fs.Parse(os.Args[1:])
+	cmd := "gen"
+	if args := fs.Args(); len(args) > 0 {
+		if args[0] == "gen" || args[0] == "decode" || args[0] == "time" {
+			cmd, args = args[0], args[1:]
+		}
+		fs.Parse(args)
+	}
+
+	switch cmd {
+	case "gen":
+		if fs.NArg() > 0 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		for i := 0; i < *n; i++ {
+			id, err := uuid.Gen()
+			if err != nil {
+				fatal(err)
+			}
+			fmt.Println(id)
+		}
+	case "decode", "time":
+		if fs.NArg() == 0 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		for _, s := range fs.Args() {
+			id, err := uuid.ParseAny(s)
+			if err != nil {
+				fatal(err)
+			}
+			t := id.Time().UTC()
+			if *local {
+				t = t.Local()
+			}
+			if cmd == "time" {
+				fmt.Println(t.Format(timeFormat))
+			} else {
+				fmt.Printf("%s\n  bytes  % x\n  time   %s\n", id, id[:], t.Format(timeFormat))
+			}
+		}
+	}
+}
+
+const timeFormat = "2006-01-02 15:04:05.000 MST"
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,46 @@
+/*
+Package codec provides uuid.UUID integrations for binary serialization formats
+which need explicit registration.
+
+Note that uuid.UUID implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, which
+both github.com/fxamacker/cbor/v2 and github.com/vmihailenco/msgpack/v5 honor by default.
+Without any registration a UUID is thus encoded as a 16-byte CBOR byte string or MessagePack
+bin value. This package adds the optional tagged CBOR form and a MessagePack extension type.
+*/
+package codec
+
+import (
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/rsms/go-uuid"
+)
+
+// CBORTag is the CBOR tag number used for tagged UUIDs; the IANA registered tag 37 for
+// binary UUIDs.
+const CBORTag = 37
+
+// AddCBORTag registers uuid.UUID with tags so that it's encoded as a 16-byte byte string
+// wrapped in CBORTag. When decoding, the tag is required.
+func AddCBORTag(tags cbor.TagSet) error {
+	opts := cbor.TagOptions{EncTag: cbor.EncTagRequired, DecTag: cbor.DecTagRequired}
+	return tags.Add(opts, reflect.TypeOf(uuid.UUID{}), CBORTag)
+}
+
+// CBORModes returns CBOR encoding and decoding modes with default options that
+// encode and decode uuid.UUID as tagged byte strings (see AddCBORTag.)
+func CBORModes() (cbor.EncMode, cbor.DecMode, error) {
+	tags := cbor.NewTagSet()
+	if err := AddCBORTag(tags); err != nil {
+		return nil, nil, err
+	}
+	em, err := cbor.EncOptions{}.EncModeWithTags(tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	dm, err := cbor.DecOptions{}.DecModeWithTags(tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return em, dm, nil
+}
@@ -0,0 +1,37 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func TestCBOR(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	// untagged: byte string via encoding.BinaryMarshaler
+	data, err := cbor.Marshal(id)
+	assert.NoErr("cbor.Marshal", err)
+	assert.Eq("untagged header is a 16-byte byte string", data[0], byte(0x50))
+	assert.Eq("untagged payload", data[1:], id[:])
+	var id2 uuid.UUID
+	assert.NoErr("cbor.Unmarshal", cbor.Unmarshal(data, &id2))
+	assert.Eq("untagged round trip", id2, id)
+
+	// tagged
+	em, dm, err := CBORModes()
+	assert.NoErr("CBORModes", err)
+	data, err = em.Marshal(id)
+	assert.NoErr("tagged Marshal", err)
+	assert.Eq("tag header", data[:2], []byte{0xd8, CBORTag})
+	assert.Eq("tagged payload", data[3:], id[:])
+	var id3 uuid.UUID
+	assert.NoErr("tagged Unmarshal", dm.Unmarshal(data, &id3))
+	assert.Eq("tagged round trip", id3, id)
+
+	untagged, _ := cbor.Marshal(id)
+	assert.Err("tag is required when decoding", "tag", dm.Unmarshal(untagged, &id3))
+}
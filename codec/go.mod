@@ -0,0 +1,20 @@
+module github.com/rsms/go-uuid/codec
+
+go 1.23.0
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
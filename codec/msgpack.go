@@ -0,0 +1,34 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/rsms/go-uuid"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// RegisterMsgpackExt registers uuid.UUID as a MessagePack extension type with the
+// application-defined type extID. The extension payload is the 16 bytes of the UUID.
+//
+// Registration is global to the msgpack package and should be done once, typically from
+// an init function. Without registration UUIDs are encoded as plain 16-byte bin values.
+func RegisterMsgpackExt(extID int8) {
+	msgpack.RegisterExtEncoder(extID, uuid.UUID{},
+		func(e *msgpack.Encoder, v reflect.Value) ([]byte, error) {
+			id := v.Interface().(uuid.UUID)
+			return id.MarshalBinary()
+		})
+	msgpack.RegisterExtDecoder(extID, uuid.UUID{},
+		func(d *msgpack.Decoder, v reflect.Value, extLen int) error {
+			if extLen != len(uuid.UUID{}) {
+				return fmt.Errorf("uuid: invalid msgpack ext length %d (expected 16)", extLen)
+			}
+			var id uuid.UUID
+			if err := d.ReadFull(id[:]); err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(id))
+			return nil
+		})
+}
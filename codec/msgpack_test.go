@@ -0,0 +1,52 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	// bin 8 with length 16 via encoding.BinaryMarshaler
+	data, err := msgpack.Marshal(id)
+	assert.NoErr("msgpack.Marshal", err)
+	assert.Eq("bin header", data[:2], []byte{0xc4, 16})
+	assert.Eq("bin payload", data[2:], id[:])
+	var id2 uuid.UUID
+	assert.NoErr("msgpack.Unmarshal", msgpack.Unmarshal(data, &id2))
+	assert.Eq("bin round trip", id2, id)
+
+	type record struct {
+		ID uuid.UUID `msgpack:"id"`
+	}
+	data, err = msgpack.Marshal(record{id})
+	assert.NoErr("msgpack.Marshal struct", err)
+	var r record
+	assert.NoErr("msgpack.Unmarshal struct", msgpack.Unmarshal(data, &r))
+	assert.Eq("struct round trip", r.ID, id)
+}
+
+func TestMsgpackExt(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	RegisterMsgpackExt(7)
+	defer msgpack.UnregisterExt(7)
+
+	data, err := msgpack.Marshal(id)
+	assert.NoErr("msgpack.Marshal", err)
+	assert.Eq("fixext 16 header", data[:2], []byte{0xd8, 7})
+	assert.Eq("ext payload", data[2:], id[:])
+	var id2 uuid.UUID
+	assert.NoErr("msgpack.Unmarshal", msgpack.Unmarshal(data, &id2))
+	assert.Eq("ext round trip", id2, id)
+
+	var v interface{}
+	assert.NoErr("msgpack.Unmarshal into interface", msgpack.Unmarshal(data, &v))
+	assert.Eq("ext decoded into interface", v, id)
+}
@@ -0,0 +1,31 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// TestYAML checks that UUIDs are encoded as strings by YAML codecs via
+// encoding.TextMarshaler. It lives here rather than next to MarshalText so that the
+// uuid module doesn't depend on a YAML package.
+func TestYAML(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	type record struct {
+		ID   uuid.UUID   `yaml:"id"`
+		Refs []uuid.UUID `yaml:"refs"`
+	}
+	id := uuid.MustGen()
+	r1 := record{id, []uuid.UUID{uuid.Min, uuid.Max}}
+	data, err := yaml.Marshal(r1)
+	assert.NoErr("yaml.Marshal", err)
+	assert.Eq("yaml", string(data),
+		"id: "+id.String()+"\nrefs:\n    - \"0\"\n    - 7n42DGM5Tflk9n8mt7Fhc7\n")
+	var r2 record
+	assert.NoErr("yaml.Unmarshal", yaml.Unmarshal(data, &r2))
+	assert.Ok("yaml round trip", reflect.DeepEqual(r2, r1))
+	assert.Err("yaml.Unmarshal invalid", "invalid character", yaml.Unmarshal([]byte("id: x-y\n"), &r2))
+}
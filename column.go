@@ -0,0 +1,120 @@
+package uuid
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// UUIDColumn is a column-oriented container of UUIDs, storing the timestamps (bytes 0-5)
+// and the random portions (bytes 6-15) of the UUIDs in two separate arrays.
+//
+// This layout suits analytics over large numbers of UUIDs: operations which only need the
+// time, like FilterTimeRange, touch just the 8-byte timestamps rather than the full 16-byte
+// UUIDs. The zero value is an empty column ready to use.
+type UUIDColumn struct {
+	ts  []uint64   // bytes 0-5 as a big-endian integer
+	rnd [][10]byte // bytes 6-15
+}
+
+// NewUUIDColumn returns a column holding a copy of ids
+func NewUUIDColumn(ids []UUID) *UUIDColumn {
+	c := &UUIDColumn{}
+	c.Append(ids...)
+	return c
+}
+
+// Len returns the number of UUIDs in the column
+func (c *UUIDColumn) Len() int { return len(c.ts) }
+
+// At returns the UUID at index i
+func (c *UUIDColumn) At(i int) UUID {
+	var id UUID
+	t := c.ts[i]
+	id[0] = byte(t >> 40)
+	id[1] = byte(t >> 32)
+	id[2] = byte(t >> 24)
+	id[3] = byte(t >> 16)
+	id[4] = byte(t >> 8)
+	id[5] = byte(t)
+	copy(id[6:], c.rnd[i][:])
+	return id
+}
+
+// Time returns the time of the UUID at index i, equivalent to c.At(i).Time()
+func (c *UUIDColumn) Time(i int) time.Time {
+	t := c.ts[i]
+	return time.Unix(int64(t>>16)+idEpochBase, int64(t&0xffff)*int64(time.Millisecond))
+}
+
+// Append adds ids to the end of the column
+func (c *UUIDColumn) Append(ids ...UUID) {
+	c.ts = slices.Grow(c.ts, len(ids))
+	c.rnd = slices.Grow(c.rnd, len(ids))
+	for i := range ids {
+		id := &ids[i]
+		c.ts = append(c.ts, uint64(id[0])<<40|uint64(id[1])<<32|uint64(id[2])<<24|
+			uint64(id[3])<<16|uint64(id[4])<<8|uint64(id[5]))
+		c.rnd = append(c.rnd, [10]byte(id[6:]))
+	}
+}
+
+// UUIDs returns the UUIDs of the column as a newly allocated slice
+func (c *UUIDColumn) UUIDs() []UUID {
+	ids := make([]UUID, len(c.ts))
+	for i := range ids {
+		ids[i] = c.At(i)
+	}
+	return ids
+}
+
+// Sort sorts the column in ascending order, the same order as bytes.Compare of the UUIDs
+func (c *UUIDColumn) Sort() {
+	sort.Sort(columnSorter{c})
+}
+
+// FilterTimeRange returns a new column with the UUIDs of c with a time in the half-open
+// range [start, end), in the same order as in c. Only the timestamps of c are scanned.
+func (c *UUIDColumn) FilterTimeRange(start, end time.Time) *UUIDColumn {
+	lo, hi := columnTimeKey(start), columnTimeKey(end)
+	r := &UUIDColumn{}
+	for i, t := range c.ts {
+		if t >= lo && t < hi {
+			r.ts = append(r.ts, t)
+			r.rnd = append(r.rnd, c.rnd[i])
+		}
+	}
+	return r
+}
+
+// columnTimeKey returns the timestamp value of t in the format of UUIDColumn.ts, truncated
+// to millisecond precision. Times outside the range of UUIDs are clamped to the smallest
+// timestamp or to one past the largest timestamp.
+func columnTimeKey(t time.Time) uint64 {
+	ms := t.UnixMilli() - idEpochBase*1000
+	if ms < 0 {
+		return 0
+	}
+	if sec := ms / 1000; sec <= math.MaxUint32 {
+		return uint64(sec)<<16 | uint64(ms%1000)
+	}
+	return 1 << 48
+}
+
+type columnSorter struct{ c *UUIDColumn }
+
+func (s columnSorter) Len() int { return len(s.c.ts) }
+
+func (s columnSorter) Less(i, j int) bool {
+	if s.c.ts[i] != s.c.ts[j] {
+		return s.c.ts[i] < s.c.ts[j]
+	}
+	return bytes.Compare(s.c.rnd[i][:], s.c.rnd[j][:]) < 0
+}
+
+func (s columnSorter) Swap(i, j int) {
+	s.c.ts[i], s.c.ts[j] = s.c.ts[j], s.c.ts[i]
+	s.c.rnd[i], s.c.rnd[j] = s.c.rnd[j], s.c.rnd[i]
+}
@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestUUIDColumn(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	var c UUIDColumn
+	assert.Eq("empty", c.Len(), 0)
+
+	start := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	g := NewSeeded(1, start)
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i], _ = g.Gen()
+	}
+	ids = append(ids, Min, Max)
+	slices.Reverse(ids)
+
+	c.Append(ids...)
+	assert.Eq("Len", c.Len(), len(ids))
+	for i, id := range ids {
+		assert.Eq("At", c.At(i), id)
+		assert.Eq("Time", c.Time(i), id.Time())
+	}
+	assert.Ok("UUIDs", reflect.DeepEqual(c.UUIDs(), ids))
+	assert.Ok("NewUUIDColumn", reflect.DeepEqual(NewUUIDColumn(ids).UUIDs(), ids))
+
+	c.Sort()
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+	assert.Ok("Sort", reflect.DeepEqual(c.UUIDs(), sorted))
+
+	// IDs are 1ms apart; [start+10ms, start+20ms) holds 10 of them
+	f := c.FilterTimeRange(start.Add(10*time.Millisecond), start.Add(20*time.Millisecond))
+	assert.Eq("FilterTimeRange len", f.Len(), 10)
+	for i := 0; i < f.Len(); i++ {
+		assert.Eq("FilterTimeRange time", f.Time(i).UTC(), start.Add(time.Duration(10+i)*time.Millisecond))
+	}
+
+	all := c.FilterTimeRange(time.Unix(0, 0), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Eq("FilterTimeRange all", all.Len(), len(ids))
+	assert.Eq("FilterTimeRange empty", c.FilterTimeRange(start, start).Len(), 0)
+}
@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Domain-separation strings, keeping the hashes computed by Combine, FromSeedString and
+// DeriveChild apart from each other and from other uses of SHA-256
+const (
+	combineDomain    = "github.com/rsms/go-uuid.Combine\x00"
+	seedStringDomain = "github.com/rsms/go-uuid.FromSeedString\x00"
+	childDomain      = "github.com/rsms/go-uuid.DeriveChild\x00"
+)
+
+// childPrefixLen is the number of leading bytes a child UUID shares with its parent
+const childPrefixLen = 10
+
+// Combine returns a UUID derived from a and b, for example an ID for the edge between a user
+// and a resource: Combine(userID, resourceID). The same inputs always yield the same UUID,
+// and the order of the inputs matters.
+//
+// The timestamp (bytes 0-5) is that of the later of a and b, so the derived UUID sorts
+// no earlier than its inputs. Bytes 6-15 are the first 10 bytes of the SHA-256 hash of a
+// domain-separation string followed by the bytes of a and b. Hashing keeps the derived
+// UUIDs uniformly distributed and unrelated to each other, even for inputs which share
+// most of their bytes, unlike Xor.
+func Combine(a, b UUID) UUID {
+	h := sha256.New()
+	h.Write([]byte(combineDomain))
+	h.Write(a[:])
+	h.Write(b[:])
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+
+	var id UUID
+	if bytes.Compare(a[:6], b[:6]) >= 0 {
+		copy(id[:6], a[:6])
+	} else {
+		copy(id[:6], b[:6])
+	}
+	copy(id[6:], sum[:])
+	return id
+}
+
+// Xor returns the bitwise exclusive or of id and other.
+// x.Xor(y) == y.Xor(x) and x.Xor(y).Xor(y) == x. The result has no meaningful timestamp
+// and shares structure with its inputs; use Combine to derive well-distributed UUIDs.
+func (id UUID) Xor(other UUID) UUID {
+	for i := range id {
+		id[i] ^= other[i]
+	}
+	return id
+}
+
+// FromSeedString returns a UUID derived from s, so that idempotent jobs can mint stable IDs
+// from natural keys like email addresses or external order numbers. The same s always
+// yields the same UUID.
+//
+// The timestamp (bytes 0-5) is zero, the default epoch, making these UUIDs sort before
+// generated ones and easy to tell apart. Bytes 6-15 are the first 10 bytes of the SHA-256
+// hash of a domain-separation string followed by s. Anybody who knows s can compute the
+// UUID, so don't derive UUIDs which must be unguessable this way.
+func FromSeedString(s string) UUID {
+	h := sha256.New()
+	h.Write([]byte(seedStringDomain))
+	h.Write([]byte(s))
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	var id UUID
+	copy(id[6:], sum[:])
+	return id
+}
+
+// DeriveChild returns the n-th child of parent, so that records which belong together,
+// like the line items of an order, get IDs which cluster next to their parent's in
+// UUID-ordered storage. The same parent and n always yield the same UUID.
+//
+// A child has the first 10 bytes of its parent (timestamp, the two bytes following it and
+// the first two random bytes) followed by the first 6 bytes of the SHA-256 hash of a
+// domain-separation string, the parent and n. Use SameParentPrefix to check if UUIDs are
+// related this way. Since only 6 bytes are derived from n, collisions among the children
+// of one parent become likely after some 16 million children.
+func DeriveChild(parent UUID, n int) UUID {
+	h := sha256.New()
+	h.Write([]byte(childDomain))
+	h.Write(parent[:])
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(n)))
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	id := parent
+	copy(id[childPrefixLen:], sum[:])
+	return id
+}
+
+// SameParentPrefix reports whether a and b share the prefix which DeriveChild gives the
+// children of a parent, i.e. whether they are the same parent, its children or siblings.
+// The prefix holds 16 random bits, so unrelated UUIDs whose first 8 bytes are equal share it
+// by chance with a probability of 1 in 65536. For UUIDs from Gen, whose bytes 6-7 hold bits
+// of the nanosecond time, that takes being generated within the same 65.536 µs to begin
+// with; UUIDs from one Generator with Sequence never share their first 8 bytes.
+func SameParentPrefix(a, b UUID) bool {
+	return string(a[:childPrefixLen]) == string(b[:childPrefixLen])
+}
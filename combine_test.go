@@ -0,0 +1,76 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestCombine(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	a := MustFromString("14dkqb8qQuruFQRFEEaUf")
+	b := MustFromString("MOpuNo4XU2HUSbBwf29A")
+
+	// derived UUIDs must never change as they are stored
+	assert.Eq("Combine", Combine(a, b).Hex(), "0091d2a50000ee6c9ddc82d29e166f94")
+	assert.Ok("order matters", Combine(a, b) != Combine(b, a))
+	assert.Ok("a is later", a.Time().After(b.Time()))
+	ab, ba := Combine(a, b), Combine(b, a)
+	assert.Eq("later timestamp", ab[:6], a[:6])
+	assert.Eq("later timestamp", ba[:6], a[:6])
+
+	// neighbouring inputs yield unrelated outputs
+	b2 := b
+	b2[15] ^= 1
+	c1, c2 := Combine(a, b), Combine(a, b2)
+	same := 0
+	for i := 6; i < 16; i++ {
+		if c1[i] == c2[i] {
+			same++
+		}
+	}
+	assert.Ok("unrelated", same < 4)
+}
+
+func TestXor(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	a, b := MustGen(), MustGen()
+	assert.Eq("commutative", a.Xor(b), b.Xor(a))
+	assert.Eq("inverse", a.Xor(b).Xor(b), a)
+	assert.Eq("self", a.Xor(a), UUID{})
+	assert.Eq("Max", a.Xor(Max).Xor(Max), a)
+}
+
+func TestFromSeedString(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := FromSeedString("alice@example.com")
+	assert.Eq("stable", id, FromSeedString("alice@example.com"))
+	assert.Eq("golden", id.Hex(), "0000000000000a8929586769086bac42")
+	assert.Eq("timestamp", id[:6], make([]byte, 6))
+	assert.Ok("differs", FromSeedString("alice@example.org") != id && FromSeedString("") != id)
+	assert.Ok("sorts before generated", id.Time().Before(MustGen().Time()))
+}
+
+func TestDeriveChild(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	parent := MustFromString("MOpuNo4XU2HUSbBwf29A")
+	c0, c1 := DeriveChild(parent, 0), DeriveChild(parent, 1)
+	assert.Eq("stable", c0, DeriveChild(parent, 0))
+	assert.Eq("golden", c0.Hex(), "0031043902c939ce146cf2934a7dc2ef")
+	assert.Ok("distinct", c0 != c1 && c0 != parent)
+	assert.Eq("timestamp", c0.Time(), parent.Time())
+	assert.Ok("parent and child", SameParentPrefix(parent, c0))
+	assert.Ok("siblings", SameParentPrefix(c0, c1))
+	assert.Ok("grandchild", SameParentPrefix(parent, DeriveChild(c1, 5)))
+
+	seen := map[UUID]bool{}
+	for n := 0; n < 1000; n++ {
+		c := DeriveChild(parent, n)
+		assert.Ok("unique", !seen[c])
+		seen[c] = true
+	}
+
+	other := MustGen()
+	assert.Ok("unrelated", !SameParentPrefix(parent, other))
+	assert.Ok("unrelated child", !SameParentPrefix(DeriveChild(other, 0), c0))
+}
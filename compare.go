@@ -0,0 +1,34 @@
+package uuid
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// EqualConstantTime reports whether id and other are equal, taking time independent of
+// their contents. Use this instead of == when comparing a UUID received from a client with
+// a secret one, for example when UUIDs are used as bearer tokens or API keys, to avoid
+// leaking information about the secret through timing.
+func (id UUID) EqualConstantTime(other UUID) bool {
+	return subtle.ConstantTimeCompare(id[:], other[:]) == 1
+}
+
+// SearchUUIDs searches for target in sorted, which must be sorted in ascending byte order
+// (e.g. with slices.SortFunc and bytes.Compare.)
+// It returns the index of target if found is true, or else the index at which target
+// would be inserted to keep sorted in order, like sort.SearchStrings.
+func SearchUUIDs(sorted []UUID, target UUID) (index int, found bool) {
+	thi := binary.BigEndian.Uint64(target[0:8])
+	tlo := binary.BigEndian.Uint64(target[8:16])
+	i, j := 0, len(sorted)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		hi := binary.BigEndian.Uint64(sorted[h][0:8])
+		if hi < thi || (hi == thi && binary.BigEndian.Uint64(sorted[h][8:16]) < tlo) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i, i < len(sorted) && sorted[i] == target
+}
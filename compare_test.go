@@ -0,0 +1,70 @@
+package uuid
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestEqualConstantTime(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	assert.Ok("equal", id.EqualConstantTime(id))
+	assert.Ok("Min == Min", Min.EqualConstantTime(UUID{}))
+	for i := range id {
+		id2 := id
+		id2[i] ^= 1
+		assert.Ok("byte %d differs", !id.EqualConstantTime(id2), i)
+	}
+}
+
+func TestSearchUUIDs(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	i, found := SearchUUIDs(nil, Max)
+	assert.Eq("empty", i, 0)
+	assert.Ok("empty", !found)
+
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = MustGen()
+	}
+	slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+
+	for i, id := range ids {
+		i2, found := SearchUUIDs(ids, id)
+		assert.Ok("found %d", found, i)
+		assert.Eq("index", i2, i)
+
+		// insertion points of missing IDs
+		if id[15] == 0 {
+			continue
+		}
+		id[15]--
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		i2, found = SearchUUIDs(ids, id)
+		assert.Ok("not found %d", !found, i)
+		assert.Eq("insertion index", i2, i)
+	}
+
+	i, found = SearchUUIDs(ids, Min)
+	assert.Ok("Min", i == 0 && !found)
+	i, found = SearchUUIDs(ids, Max)
+	assert.Ok("Max", i == len(ids) && !found)
+}
+
+func BenchmarkSearchUUIDs(b *testing.B) {
+	ids := make([]UUID, 1000000)
+	for i := range ids {
+		ids[i] = MustGen()
+	}
+	slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SearchUUIDs(ids, ids[i%len(ids)])
+	}
+}
@@ -0,0 +1,66 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AppendCompressed appends a compact encoding of ids to dst and returns the extended
+// buffer. ids must be sorted in ascending byte order (see SearchUUIDs) or an error is
+// returned. Duplicates are allowed.
+//
+// Sorted UUIDs share most of their timestamp with their predecessor, so rather than storing
+// 16 bytes per UUID, the first 8 bytes (timestamp and the two bytes following it) are stored
+// as a varint-encoded difference to the previous UUID, followed by the last 8 bytes
+// verbatim. UUIDs generated within a few milliseconds of each other take up 10-11 bytes.
+//
+// The encoding is a uvarint count followed by that many (uvarint delta, 8 bytes) pairs.
+// Use DecodeCompressed to decode it.
+func AppendCompressed(dst []byte, ids []UUID) ([]byte, error) {
+	dst = binary.AppendUvarint(dst, uint64(len(ids)))
+	var prev uint64
+	for i := range ids {
+		hi := binary.BigEndian.Uint64(ids[i][0:8])
+		if hi < prev || (i > 0 && hi == prev && string(ids[i][8:]) < string(ids[i-1][8:])) {
+			return dst, fmt.Errorf("uuid: UUIDs not sorted at index %d", i)
+		}
+		dst = binary.AppendUvarint(dst, hi-prev)
+		dst = append(dst, ids[i][8:]...)
+		prev = hi
+	}
+	return dst, nil
+}
+
+// DecodeCompressed decodes data produced by AppendCompressed, appending the UUIDs to ids
+// and returning the extended slice.
+func DecodeCompressed(data []byte, ids []UUID) ([]UUID, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return ids, fmt.Errorf("uuid: invalid compressed data")
+	}
+	data = data[n:]
+	// every entry is at least 9 bytes long
+	if count > uint64(len(data)/9) {
+		return ids, fmt.Errorf("uuid: compressed data truncated")
+	}
+	ids = append(ids, make([]UUID, count)...)
+	dst := ids[len(ids)-int(count):]
+	var hi uint64
+	for i := range dst {
+		delta, n := binary.Uvarint(data)
+		if n <= 0 || len(data)-n < 8 {
+			return ids[:len(ids)-len(dst)+i], fmt.Errorf("uuid: compressed data truncated")
+		}
+		if hi+delta < hi {
+			return ids[:len(ids)-len(dst)+i], fmt.Errorf("uuid: invalid compressed data")
+		}
+		hi += delta
+		binary.BigEndian.PutUint64(dst[i][0:8], hi)
+		copy(dst[i][8:], data[n:n+8])
+		data = data[n+8:]
+	}
+	if len(data) != 0 {
+		return ids, fmt.Errorf("uuid: %d bytes of trailing data after compressed UUIDs", len(data))
+	}
+	return ids, nil
+}
@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestCompressed(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	g := NewSeeded(1, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i], _ = g.Gen()
+	}
+	ids = append(ids, ids[999], Max) // duplicates are allowed
+
+	data, err := AppendCompressed(nil, ids)
+	assert.NoErr("AppendCompressed", err)
+	// about 11 bytes per UUID, plus the full timestamp of the first one and Max
+	assert.Ok("size %d", len(data) < len(ids)*11+32, len(data))
+
+	ids2, err := DecodeCompressed(data, nil)
+	assert.NoErr("DecodeCompressed", err)
+	assert.Ok("DecodeCompressed(AppendCompressed())", reflect.DeepEqual(ids2, ids))
+
+	// appends to existing data
+	prefix := []UUID{Min}
+	ids3, err := DecodeCompressed(data, prefix)
+	assert.NoErr("DecodeCompressed", err)
+	assert.Ok("DecodeCompressed append", reflect.DeepEqual(ids3, append(prefix, ids...)))
+
+	// empty
+	data, err = AppendCompressed([]byte{1, 2}, nil)
+	assert.NoErr("AppendCompressed empty", err)
+	assert.Eq("AppendCompressed empty", data, []byte{1, 2, 0})
+	ids2, err = DecodeCompressed(data[2:], nil)
+	assert.NoErr("DecodeCompressed empty", err)
+	assert.Eq("DecodeCompressed empty", len(ids2), 0)
+}
+
+func TestCompressedInvalid(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	ids := []UUID{MustGen(), MustGen(), MustGen()}
+	slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+	_, err := AppendCompressed(nil, []UUID{ids[1], ids[0]})
+	assert.Err("unsorted", "not sorted at index 1", err)
+	a, b := Max, Max
+	a[15] = 0
+	_, err = AppendCompressed(nil, []UUID{b, a})
+	assert.Err("unsorted low bytes", "not sorted at index 1", err)
+
+	data, _ := AppendCompressed(nil, ids)
+	_, err = DecodeCompressed(nil, nil)
+	assert.Err("no data", "invalid compressed data", err)
+	_, err = DecodeCompressed(data[:len(data)-1], nil)
+	assert.Err("truncated", "truncated", err)
+	_, err = DecodeCompressed(append(data, 0), nil)
+	assert.Err("trailing data", "1 bytes of trailing data", err)
+	_, err = DecodeCompressed([]byte{255, 255, 255, 255, 15}, nil)
+	assert.Err("huge count", "truncated", err)
+
+	// delta overflowing 64 bits
+	data, _ = AppendCompressed(nil, []UUID{Max, Max})
+	data[1+10+8] = 1
+	_, err = DecodeCompressed(data, nil)
+	assert.Err("overflow", "invalid compressed data", err)
+}
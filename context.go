@@ -0,0 +1,18 @@
+package uuid
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id, which FromContext retrieves.
+// This lets a request-scoped ID, like a request or trace ID, propagate through calls
+// without each package defining its own context key.
+func NewContext(ctx context.Context, id UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the UUID stored in ctx by NewContext, if any
+func FromContext(ctx context.Context) (UUID, bool) {
+	id, ok := ctx.Value(contextKey{}).(UUID)
+	return id, ok
+}
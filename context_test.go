@@ -0,0 +1,26 @@
+package uuid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestContext(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	_, ok := FromContext(context.Background())
+	assert.Ok("no UUID", !ok)
+
+	a, b := MustGen(), MustGen()
+	ctx := NewContext(context.Background(), a)
+	id, ok := FromContext(ctx)
+	assert.Ok("FromContext", ok)
+	assert.Eq("FromContext", id, a)
+
+	ctx2 := NewContext(ctx, b)
+	id, _ = FromContext(ctx2)
+	assert.Eq("inner", id, b)
+	id, _ = FromContext(ctx)
+	assert.Eq("outer unchanged", id, a)
+}
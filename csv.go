@@ -0,0 +1,107 @@
+package uuid
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// CSVColumnReader reads UUIDs from one column of CSV data, for example when importing
+// rows exported from another database. Fields may hold UUIDs in any form accepted by
+// ParseAny, like the string form of String() or hexadecimal as used by other databases.
+//
+// Invalid fields are reported as *csv.ParseError with the line and column of the field,
+// wrapping the error of the decoder.
+type CSVColumnReader struct {
+	// CSV is the underlying CSV reader. Its options, like Comma or Comment, may be changed
+	// before the first call to Read.
+	CSV *csv.Reader
+
+	Column int  // index of the field holding UUIDs
+	Header bool // skip the first record
+
+	started bool
+}
+
+// NewCSVColumnReader returns a reader of UUIDs in field column of the CSV records in r
+func NewCSVColumnReader(r io.Reader, column int) *CSVColumnReader {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSVColumnReader{CSV: cr, Column: column}
+}
+
+// Read returns the UUID of the next record. It returns io.EOF at the end of the input.
+// An error is returned without reading any input if Column is negative.
+func (r *CSVColumnReader) Read() (UUID, error) {
+	if r.Column < 0 {
+		return UUID{}, fmt.Errorf("uuid: invalid CSV column %d", r.Column)
+	}
+	if !r.started {
+		r.started = true
+		if r.Header {
+			if _, err := r.CSV.Read(); err != nil {
+				return UUID{}, err
+			}
+		}
+	}
+	record, err := r.CSV.Read()
+	if err != nil {
+		return UUID{}, err
+	}
+	if r.Column >= len(record) {
+		line, _ := r.CSV.FieldPos(0)
+		return UUID{}, &csv.ParseError{
+			StartLine: line,
+			Line:      line,
+			Err:       fmt.Errorf("uuid: record has %d fields, missing column %d", len(record), r.Column),
+		}
+	}
+	id, err := ParseAny(record[r.Column])
+	if err != nil {
+		line, col := r.CSV.FieldPos(r.Column)
+		return UUID{}, &csv.ParseError{StartLine: line, Line: line, Column: col, Err: err}
+	}
+	return id, nil
+}
+
+// DecodeCSVColumn returns an iterator over the UUIDs in field column of the CSV records in
+// r (see CSVColumnReader.) If reading or decoding fails, the error is yielded (with a zero
+// UUID) and the iteration ends. For example:
+//
+//	for id, err := range uuid.DecodeCSVColumn(f, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func DecodeCSVColumn(r io.Reader, column int) iter.Seq2[UUID, error] {
+	return func(yield func(UUID, error) bool) {
+		cr := NewCSVColumnReader(r, column)
+		for {
+			id, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(id, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// EncodeCSVColumn writes ids to w as a CSV file with a single column, one UUID per line in
+// its string form (see String.) Output is buffered; w is written to in large chunks.
+func EncodeCSVColumn(w io.Writer, ids iter.Seq[UUID]) error {
+	bw := bufio.NewWriterSize(w, 64*1024)
+	var line [StringMaxLen + 1]byte
+	for id := range ids {
+		n := id.EncodeString(line[:StringMaxLen])
+		line[StringMaxLen] = '\n'
+		if _, err := bw.Write(line[n:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,97 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestCSVColumn(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = MustGen()
+	}
+	ids[0] = UUID{} // shortest string
+
+	var buf bytes.Buffer
+	assert.NoErr("EncodeCSVColumn", EncodeCSVColumn(&buf, slices.Values(ids)))
+	assert.Eq("line count", strings.Count(buf.String(), "\n"), len(ids))
+	assert.Eq("first line", strings.SplitN(buf.String(), "\n", 2)[0], ids[0].String())
+
+	var got []UUID
+	for id, err := range DecodeCSVColumn(&buf, 0) {
+		assert.NoErr("DecodeCSVColumn", err)
+		got = append(got, id)
+	}
+	assert.Ok("round trip", slices.Equal(got, ids))
+}
+
+func TestCSVColumnReader(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	a, b := MustGen(), MustGen()
+	input := "name,id\n" +
+		"a,\"" + a.String() + "\"\n" +
+		"b," + b.Hex() + "\n" +
+		"c," + strings.ToUpper(a.URN()[9:]) + "\n"
+	r := NewCSVColumnReader(strings.NewReader(input), 1)
+	r.Header = true
+	for _, want := range []UUID{a, b, a} {
+		id, err := r.Read()
+		assert.NoErr("Read", err)
+		assert.Eq("Read", id, want)
+	}
+	_, err := r.Read()
+	assert.Eq("EOF", err, io.EOF)
+}
+
+func TestCSVColumnReaderErrors(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	a := MustGen().String()
+
+	r := NewCSVColumnReader(strings.NewReader(a+",x\n"+a+",x\n"+a+"!,x\n"), 0)
+	_, err := r.Read()
+	assert.NoErr("line 1", err)
+	_, err = r.Read()
+	assert.NoErr("line 2", err)
+	_, err = r.Read()
+	var perr *csv.ParseError
+	assert.Ok("ParseError", errors.As(err, &perr))
+	assert.Eq("Line", perr.Line, 3)
+	assert.Eq("Column", perr.Column, 1)
+	assert.Err("message", "line 3, column 1", err)
+
+	r = NewCSVColumnReader(strings.NewReader("x,"+a+"!\n"), 1)
+	_, err = r.Read()
+	assert.Ok("ParseError", errors.As(err, &perr))
+	assert.Eq("Column", perr.Column, 3)
+	var cerr *InvalidCharError
+	assert.Ok("wraps InvalidCharError", errors.As(err, &cerr))
+
+	r = NewCSVColumnReader(strings.NewReader(a+"\n"), 2)
+	_, err = r.Read()
+	assert.Err("missing column", "line 1, column 0: uuid: record has 1 fields, missing column 2", err)
+	_, err = NewCSVColumnReader(strings.NewReader(a+"\n"), -1).Read()
+	assert.Err("negative column", "uuid: invalid CSV column -1", err)
+	errs := 0
+	for _, err := range DecodeCSVColumn(strings.NewReader(a+"\n"), -1) {
+		assert.Err("DecodeCSVColumn negative column", "uuid: invalid CSV column -1", err)
+		errs++
+	}
+	assert.Eq("DecodeCSVColumn negative column errors", errs, 1)
+
+	n := 0
+	for _, err := range DecodeCSVColumn(strings.NewReader(a+"\nnope-\n"+a+"\n"), 0) {
+		n++
+		if n == 2 {
+			assert.Err("DecodeCSVColumn", "line 2", err)
+		}
+	}
+	assert.Eq("DecodeCSVColumn stops at error", n, 2)
+}
@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduper records recently seen UUIDs and reports duplicates, for example in ingest
+// pipelines receiving records from many producers which may retry or misbehave.
+// A Deduper is safe for concurrent use.
+//
+// Memory is bounded: once maxEntries UUIDs are remembered, the UUID seen longest ago is
+// forgotten to make room for a new one. UUIDs may also be forgotten after a time to live.
+// A forgotten UUID is no longer reported as a duplicate when seen again.
+type Deduper struct {
+	mu         sync.Mutex
+	seen       map[UUID]int64 // time each UUID was recorded, in Unix nanoseconds
+	ring       []UUID         // UUIDs in the order they were recorded, starting at head
+	head       int
+	count      int
+	ttl        time.Duration
+	now        func() time.Time
+	duplicates uint64
+}
+
+// NewDeduper returns a Deduper remembering up to maxEntries UUIDs. If ttl is positive,
+// UUIDs are also forgotten once ttl has passed since they were first seen.
+// NewDeduper panics if maxEntries <= 0.
+func NewDeduper(maxEntries int, ttl time.Duration) *Deduper {
+	if maxEntries <= 0 {
+		panic("uuid: invalid argument to NewDeduper")
+	}
+	return &Deduper{
+		seen: make(map[UUID]int64, maxEntries),
+		ring: make([]UUID, maxEntries),
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// Seen records id and reports whether it has been seen before, i.e. is a duplicate
+func (d *Deduper) Seen(id UUID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now().UnixNano()
+	d.expire(now)
+	if _, ok := d.seen[id]; ok {
+		d.duplicates++
+		return true
+	}
+	if d.count == len(d.ring) {
+		d.evict()
+	}
+	d.ring[(d.head+d.count)%len(d.ring)] = id
+	d.count++
+	d.seen[id] = now
+	return false
+}
+
+// Len returns the number of UUIDs currently remembered
+func (d *Deduper) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expire(d.now().UnixNano())
+	return d.count
+}
+
+// Duplicates returns the number of times Seen has reported a duplicate
+func (d *Deduper) Duplicates() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.duplicates
+}
+
+// expire forgets UUIDs recorded more than ttl before now. Since UUIDs are recorded in
+// order of time, these are all at the start of the ring. The caller must hold d.mu.
+func (d *Deduper) expire(now int64) {
+	if d.ttl <= 0 {
+		return
+	}
+	cutoff := now - int64(d.ttl)
+	for d.count > 0 && d.seen[d.ring[d.head]] < cutoff {
+		d.evict()
+	}
+}
+
+// evict forgets the UUID recorded longest ago. The caller must hold d.mu.
+func (d *Deduper) evict() {
+	delete(d.seen, d.ring[d.head])
+	d.ring[d.head] = UUID{}
+	d.head = (d.head + 1) % len(d.ring)
+	d.count--
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestDeduper(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	d := NewDeduper(1000, 0)
+	a, b := MustGen(), MustGen()
+	assert.Ok("first a", !d.Seen(a))
+	assert.Ok("first b", !d.Seen(b))
+	assert.Ok("duplicate a", d.Seen(a))
+	assert.Ok("duplicate b", d.Seen(b))
+	assert.Eq("Len", d.Len(), 2)
+	assert.Eq("Duplicates", d.Duplicates(), uint64(2))
+
+	assert.Panic("invalid argument to NewDeduper", func() { NewDeduper(0, 0) })
+}
+
+func TestDeduperBounded(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	d := NewDeduper(64, 0)
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = MustGen()
+		assert.Ok("new", !d.Seen(ids[i]))
+		assert.Eq("Len", d.Len(), min(i+1, 64))
+	}
+	// exactly the 64 most recently seen UUIDs are remembered
+	for i := len(ids) - 1; i >= len(ids)-64; i-- {
+		assert.Ok("recent remembered", d.Seen(ids[i]))
+	}
+	assert.Ok("older forgotten", !d.Seen(ids[len(ids)-65]))
+
+	small := NewDeduper(3, 0)
+	for _, id := range ids[:10] {
+		small.Seen(id)
+	}
+	assert.Eq("small Len", small.Len(), 3)
+	assert.Ok("small remembers last", small.Seen(ids[9]) && small.Seen(ids[8]) && small.Seen(ids[7]))
+	assert.Ok("small forgot earlier", !small.Seen(ids[6]))
+}
+
+func TestDeduperTTL(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	now := time.Unix(1700000000, 0)
+	d := NewDeduper(100, time.Minute)
+	d.now = func() time.Time { return now }
+	a, b := MustGen(), MustGen()
+	d.Seen(a)
+	now = now.Add(30 * time.Second)
+	d.Seen(b)
+	assert.Ok("a within TTL", d.Seen(a))
+	now = now.Add(31 * time.Second)
+	assert.Ok("a expired", !d.Seen(a))
+	assert.Ok("b within TTL", d.Seen(b))
+	now = now.Add(time.Hour)
+	assert.Ok("b expired", !d.Seen(b))
+	assert.Eq("Len", d.Len(), 1)
+}
+
+func TestDeduperConcurrent(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	d := NewDeduper(10000, 0)
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = MustGen()
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	newCount := 0
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for _, id := range ids {
+				if !d.Seen(id) {
+					n++
+				}
+			}
+			mu.Lock()
+			newCount += n
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Eq("each UUID new exactly once", newCount, len(ids))
+	assert.Eq("Duplicates", d.Duplicates(), uint64(7*len(ids)))
+}
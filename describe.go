@@ -0,0 +1,21 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Describe returns a human-readable breakdown of the UUID for debugging, e.g.
+//
+//	"MOpuNo4XU2HUSbBwf29A: time 2020-10-20 16:45:45.713 UTC (3212345 s + 713 ms since
+//	2020-09-13 12:26:40 UTC), random 39ce146c0bdba1407778"
+//
+// (as one line.) The time is in UTC and the random bytes (bytes 6-15) are in hexadecimal.
+// The format is intended for humans and may change.
+func (id UUID) Describe() string {
+	sec, ms := id.Timestamp()
+	random := id.Random()
+	return fmt.Sprintf("%s: time %s (%d s + %d ms since %s), random %s",
+		id, id.Time().UTC().Format("2006-01-02 15:04:05.000 MST"), sec, ms,
+		Min.Time().UTC().Format("2006-01-02 15:04:05 MST"), hex.EncodeToString(random[:]))
+}
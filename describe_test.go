@@ -0,0 +1,14 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestDescribe(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id, _ := FromHex("0031043902c939ce146c0bdba1407778")
+	assert.Eq("Describe", id.Describe(), id.String()+": time 2020-10-20 16:45:45.713 UTC "+
+		"(3212345 s + 713 ms since 2020-09-13 12:26:40 UTC), random 39ce146c0bdba1407778")
+}
@@ -0,0 +1,66 @@
+// Package dynamodbuuid integrates github.com/rsms/go-uuid UUIDs with the attributevalue
+// package of the AWS SDK for Go v2, github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue.
+//
+// Without it, a uuid.UUID is encoded as a list of 16 numbers. Instead, declare UUID fields
+// with one of the wrapper types of this package, choosing how they are stored:
+//
+//	type Item struct {
+//		ID    dynamodbuuid.Binary `dynamodbav:"id"`    // B attribute with the 16 bytes
+//		Owner dynamodbuuid.String `dynamodbav:"owner"` // S attribute with the padded base62 string
+//	}
+//
+// Both representations sort in the same order as the UUIDs when used as sort keys.
+// Both wrapper types decode either representation, which helps when migrating a table
+// from one to the other.
+package dynamodbuuid
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/rsms/go-uuid"
+)
+
+// Binary wraps uuid.UUID to be stored as a binary (B) attribute holding the 16 bytes of
+// the UUID. It implements attributevalue.Marshaler and attributevalue.Unmarshaler.
+type Binary uuid.UUID
+
+// String wraps uuid.UUID to be stored as a string (S) attribute holding the string
+// representation returned by uuid.UUID.StringPadded. It implements attributevalue.Marshaler
+// and attributevalue.Unmarshaler.
+type String uuid.UUID
+
+// MarshalDynamoDBAttributeValue implements the attributevalue.Marshaler interface
+func (id Binary) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return &types.AttributeValueMemberB{Value: b}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements the attributevalue.Unmarshaler interface
+func (id *Binary) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return decode((*uuid.UUID)(id), av)
+}
+
+// MarshalDynamoDBAttributeValue implements the attributevalue.Marshaler interface
+func (id String) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: uuid.UUID(id).StringPadded()}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements the attributevalue.Unmarshaler interface
+func (id *String) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return decode((*uuid.UUID)(id), av)
+}
+
+func decode(id *uuid.UUID, av types.AttributeValue) error {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberB:
+		return id.UnmarshalBinary(av.Value)
+	case *types.AttributeValueMemberS:
+		return id.UnmarshalText([]byte(av.Value))
+	case *types.AttributeValueMemberNULL:
+		*id = uuid.UUID{}
+		return nil
+	}
+	return fmt.Errorf("uuid: cannot decode DynamoDB attribute of type %T into UUID", av)
+}
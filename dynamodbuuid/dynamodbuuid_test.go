@@ -0,0 +1,56 @@
+package dynamodbuuid
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+type item struct {
+	ID    Binary `dynamodbav:"id"`
+	Owner String `dynamodbav:"owner"`
+}
+
+func TestMarshal(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id1, id2 := uuid.MustGen(), uuid.MustGen()
+
+	av, err := attributevalue.MarshalMap(item{Binary(id1), String(id2)})
+	assert.NoErr("MarshalMap", err)
+	b, ok := av["id"].(*types.AttributeValueMemberB)
+	assert.Ok("id is B", ok)
+	assert.Eq("id bytes", b.Value, id1[:])
+	s, ok := av["owner"].(*types.AttributeValueMemberS)
+	assert.Ok("owner is S", ok)
+	assert.Eq("owner string", s.Value, id2.StringPadded())
+
+	var it item
+	assert.NoErr("UnmarshalMap", attributevalue.UnmarshalMap(av, &it))
+	assert.Eq("ID", uuid.UUID(it.ID), id1)
+	assert.Eq("Owner", uuid.UUID(it.Owner), id2)
+
+	// either representation decodes into either type
+	av["id"], av["owner"] = av["owner"], av["id"]
+	it = item{}
+	assert.NoErr("UnmarshalMap swapped", attributevalue.UnmarshalMap(av, &it))
+	assert.Eq("ID from S", uuid.UUID(it.ID), id2)
+	assert.Eq("Owner from B", uuid.UUID(it.Owner), id1)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	var id Binary
+	assert.Err("N", "cannot decode DynamoDB attribute of type *types.AttributeValueMemberN",
+		id.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberN{Value: "1"}))
+	assert.Err("short B", "invalid binary data length 3",
+		id.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberB{Value: []byte{1, 2, 3}}))
+	assert.Err("bad S", "invalid character",
+		id.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberS{Value: "abc-def"}))
+
+	id = Binary(uuid.MustGen())
+	assert.NoErr("NULL", id.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberNULL{Value: true}))
+	assert.Eq("NULL", uuid.UUID(id), uuid.Min)
+}
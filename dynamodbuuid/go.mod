@@ -0,0 +1,19 @@
+module github.com/rsms/go-uuid/dynamodbuuid
+
+go 1.24.0
+
+require (
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.21.7
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.69.1
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.43.0 // indirect
+	github.com/aws/smithy-go v1.28.1 // indirect
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
@@ -0,0 +1,149 @@
+package uuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The encoded form is always exactly the 16 bytes of the UUID.
+//
+// Many codecs, like CBOR and MessagePack encoders, use this to encode a UUID as a compact
+// byte string rather than as an array of 16 integers.
+func (id UUID) MarshalBinary() ([]byte, error) {
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return b, nil
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface,
+// appending the 16 bytes of the UUID to b.
+func (id UUID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// data must be exactly 16 bytes long.
+func (id *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(id) {
+		return fmt.Errorf("uuid: invalid binary data length %d (expected %d)", len(data), len(id))
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The encoded form is the same as returned by String().
+//
+// This makes UUIDs appear as strings in text-based formats like JSON and YAML.
+func (id UUID) MarshalText() ([]byte, error) {
+	var buf [StringMaxLen]byte
+	n := id.EncodeString(buf[:])
+	b := make([]byte, StringMaxLen-n)
+	copy(b, buf[n:])
+	return b, nil
+}
+
+// AppendText implements the encoding.TextAppender interface,
+// appending the string representation of the UUID (as returned by String()) to b.
+func (id UUID) AppendText(b []byte) ([]byte, error) {
+	var buf [StringMaxLen]byte
+	n := id.EncodeString(buf[:])
+	return append(b, buf[n:]...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the string representation returned by String().
+func (id *UUID) UnmarshalText(text []byte) error {
+	return id.DecodeStringStrict(text)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a JSON string of
+// the string representation returned by String(), as encoded by MarshalText, and for
+// compatibility with JSON encoded by earlier versions of this package, which didn't
+// implement encoding.TextMarshaler, an array of the 16 bytes as numbers. null is a no-op.
+func (id *UUID) UnmarshalJSON(data []byte) error {
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		var a []uint
+		if err := json.Unmarshal(data, &a); err != nil {
+			return fmt.Errorf("uuid: invalid JSON array: %w", err)
+		}
+		if len(a) != len(id) {
+			return fmt.Errorf("uuid: invalid JSON array length %d (expected %d)", len(a), len(id))
+		}
+		var b UUID
+		for i, v := range a {
+			if v > 0xff {
+				return fmt.Errorf("uuid: invalid JSON array element %d at index %d", v, i)
+			}
+			b[i] = byte(v)
+		}
+		*id = b
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("uuid: invalid JSON value: %w", err)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The encoded form is always exactly the 16 bytes of the UUID.
+func (id UUID) GobEncode() ([]byte, error) {
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return b, nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// data must be exactly 16 bytes long, as produced by GobEncode.
+func (id *UUID) GobDecode(data []byte) error {
+	if len(data) != len(id) {
+		return fmt.Errorf("uuid: invalid gob data length %d (expected %d)", len(data), len(id))
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// Set implements the flag.Value interface, setting id to the decoded value of the
+// string representation s (as returned by String()). This allows a *UUID to be used with
+// flag.Var, e.g.
+//
+//	var id uuid.UUID
+//	flag.Var(&id, "id", "object to operate on")
+func (id *UUID) Set(s string) error {
+	return id.DecodeStringStrict([]byte(s))
+}
+
+// Type returns the name of the value type, "uuid", for use with the pflag package
+// which extends the flag.Value interface with this method.
+func (id *UUID) Type() string {
+	return "uuid"
+}
+
+// Scan implements the fmt.Scanner interface, reading the string representation of a UUID
+// (as returned by String()) for the verbs %v and %s. This allows UUIDs to be read with
+// fmt.Sscan, fmt.Fscan and friends, e.g.
+//
+//	var a, b uuid.UUID
+//	_, err := fmt.Sscan("14dkqb8qQuruFQRFEEaUf 1cd4pgZR4ZDRaRq3JYcWy", &a, &b)
+//
+// Leading space is skipped and the UUID ends at the next space or end of input.
+func (id *UUID) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' {
+		return fmt.Errorf("uuid: invalid verb %%%c for scanning a UUID", verb)
+	}
+	tok, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 {
+		return io.EOF
+	}
+	return id.DecodeStringStrict(tok)
+}
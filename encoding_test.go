@@ -0,0 +1,181 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestBinary(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id := MustGen()
+	data, err := id.MarshalBinary()
+	assert.NoErr("MarshalBinary", err)
+	assert.Eq("MarshalBinary bytes", data, id[:])
+	data[0] ^= 0xff
+	assert.Ok("MarshalBinary returns a copy", data[0] != id[0])
+	data[0] ^= 0xff
+
+	var id2 UUID
+	assert.NoErr("UnmarshalBinary", id2.UnmarshalBinary(data))
+	assert.Eq("UnmarshalBinary(MarshalBinary())", id2, id)
+	assert.Err("UnmarshalBinary short", "invalid binary data length", id2.UnmarshalBinary(data[:3]))
+}
+
+func TestAppend(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// encoding.TextAppender and encoding.BinaryAppender of Go 1.24
+	var _ interface {
+		AppendText([]byte) ([]byte, error)
+		AppendBinary([]byte) ([]byte, error)
+	} = UUID{}
+
+	id := MustGen()
+	b, err := id.AppendText([]byte("id="))
+	assert.NoErr("AppendText", err)
+	assert.Eq("AppendText", string(b), "id="+id.String())
+	b, _ = Min.AppendText(nil)
+	assert.Eq("AppendText Min", string(b), "0")
+
+	b, err = id.AppendBinary([]byte{1, 2})
+	assert.NoErr("AppendBinary", err)
+	assert.Eq("AppendBinary", b, append([]byte{1, 2}, id[:]...))
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = id.AppendText(buf[:0])
+		buf, _ = id.AppendBinary(buf)
+	})
+	assert.Eq("no allocations with sufficient capacity", allocs, 0.0)
+}
+
+func TestGob(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id := MustGen()
+	data, err := id.GobEncode()
+	assert.NoErr("GobEncode", err)
+	assert.Eq("GobEncode length", len(data), 16)
+	assert.Eq("GobEncode bytes", data, id[:])
+
+	var id2 UUID
+	assert.NoErr("GobDecode", id2.GobDecode(data))
+	assert.Eq("GobDecode(GobEncode())", id2, id)
+	assert.Err("GobDecode short", "invalid gob data length", id2.GobDecode(data[:15]))
+	assert.Err("GobDecode long", "invalid gob data length", id2.GobDecode(append(data, 0)))
+
+	// round trip through an actual gob stream, as a value and as a struct field
+	type record struct {
+		ID   UUID
+		Name string
+	}
+	var buf bytes.Buffer
+	assert.NoErr("gob Encode", gob.NewEncoder(&buf).Encode(record{id, "a"}))
+	var r record
+	assert.NoErr("gob Decode", gob.NewDecoder(&buf).Decode(&r))
+	assert.Eq("gob round trip", r, record{id, "a"})
+}
+
+func TestFlagValue(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id1 := MustGen()
+	var id UUID
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&id, "id", "")
+	assert.NoErr("Parse", fs.Parse([]string{"-id=" + id1.String()}))
+	assert.Eq("flag value", id, id1)
+	assert.Eq("flag String", fs.Lookup("id").Value.String(), id1.String())
+
+	assert.Err("Parse invalid char", `invalid character '-'`, fs.Parse([]string{"-id=abc-def"}))
+	assert.Err("Parse too long", "invalid string length 23",
+		fs.Parse([]string{"-id=" + Max.String() + "0"}))
+	assert.Err("Parse empty", "invalid string length 0", fs.Parse([]string{"-id="}))
+	assert.Eq("Type", id.Type(), "uuid")
+}
+
+func TestText(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id1 := MustGen()
+	text, err := id1.MarshalText()
+	assert.NoErr("MarshalText", err)
+	assert.Eq("MarshalText", string(text), id1.String())
+	text, _ = Min.MarshalText()
+	assert.Eq("MarshalText Min", string(text), "0")
+
+	var id UUID
+	assert.NoErr("UnmarshalText", id.UnmarshalText([]byte(id1.String())))
+	assert.Eq("UnmarshalText(MarshalText())", id, id1)
+	assert.Err("UnmarshalText invalid", `invalid character '!'`, id.UnmarshalText([]byte("abc!")))
+
+	// JSON
+	type record struct {
+		ID   UUID   `json:"id"`
+		Refs []UUID `json:"refs"`
+	}
+	r1 := record{id1, []UUID{Min, Max}}
+	data, err := json.Marshal(r1)
+	assert.NoErr("json.Marshal", err)
+	assert.Eq("json", string(data),
+		`{"id":"`+id1.String()+`","refs":["0","7n42DGM5Tflk9n8mt7Fhc7"]}`)
+	var r2 record
+	assert.NoErr("json.Unmarshal", json.Unmarshal(data, &r2))
+	assert.Ok("json round trip", reflect.DeepEqual(r2, r1))
+	assert.Err("json.Unmarshal invalid", "invalid character", json.Unmarshal([]byte(`{"id":"x_y"}`), &r2))
+
+	// legacy arrays of 16 numbers, as encoded before UUID implemented TextMarshaler
+	legacy, err := json.Marshal([16]byte(id1))
+	assert.NoErr("json.Marshal array", err)
+	assert.Eq("legacy array", string(legacy)[0], byte('['))
+	var r4 record
+	data = []byte(`{"id":` + string(legacy) +
+		`,"refs":[[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],"7n42DGM5Tflk9n8mt7Fhc7"]}`)
+	assert.NoErr("json.Unmarshal legacy", json.Unmarshal(data, &r4))
+	assert.Ok("json legacy", reflect.DeepEqual(r4, r1))
+	assert.Err("json legacy short", "invalid JSON array length 2",
+		json.Unmarshal([]byte(`{"id":[1,2]}`), &r4))
+	assert.Err("json legacy element", "invalid JSON array element 256",
+		json.Unmarshal([]byte(`{"id":[256,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]}`), &r4))
+	assert.Err("json legacy negative", "invalid JSON array",
+		json.Unmarshal([]byte(`{"id":[-1,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]}`), &r4))
+	assert.Err("json number", "invalid JSON value", json.Unmarshal([]byte(`{"id":1}`), &r4))
+	r4 = record{ID: id1}
+	assert.NoErr("json null", json.Unmarshal([]byte(`{"id":null}`), &r4))
+	assert.Eq("json null is a no-op", r4.ID, id1)
+}
+
+func TestScanFmt(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id1, id2 := MustGen(), MustGen()
+
+	var a, b UUID
+	n, err := fmt.Sscan("  "+id1.String()+"\n"+id2.String()+" ", &a, &b)
+	assert.NoErr("Sscan", err)
+	assert.Eq("Sscan count", n, 2)
+	assert.Eq("Sscan a", a, id1)
+	assert.Eq("Sscan b", b, id2)
+
+	var c UUID
+	var i int
+	n, err = fmt.Sscanf(id1.String()+" 123", "%s %d", &c, &i)
+	assert.NoErr("Sscanf", err)
+	assert.Ok("Sscanf", n == 2 && c == id1 && i == 123)
+
+	_, err = fmt.Sscan("abc-def", &c)
+	assert.Err("Sscan invalid", "invalid character '-' at offset 3", err)
+	_, err = fmt.Sscanf(id1.String(), "%d", &c)
+	assert.Err("Sscanf %d", "invalid verb %d", err)
+	_, err = fmt.Sscan("", &c)
+	assert.Err("Sscan empty", "EOF", err)
+}
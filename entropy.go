@@ -0,0 +1,167 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	mathrand "math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// entropyPool is a buffered reader of crypto/rand, reducing the number of syscalls needed
+// when generating many UUIDs. Bytes are zeroed in the buffer once handed out.
+type entropyPool struct {
+	mu  sync.Mutex
+	buf *[entropyPoolSize]byte // allocated on first use
+	off int                    // offset of the next unused byte in buf
+	_   [64]byte               // keeps pools in separate cache lines
+}
+
+// entropyShards spreads the readers of random bytes over several entropyPools, one per P
+// (rounded up to a power of two) as the runtime does for its own random generator, so that
+// goroutines generating UUIDs in parallel rarely wait for each other.
+type entropyShards struct {
+	pools []entropyPool
+}
+
+// entropy is the set of pools shared by Gen and Generators
+var entropy = newEntropyShards(runtime.GOMAXPROCS(0))
+
+// maxEntropyShards limits the memory used for buffers on machines with many cores
+const maxEntropyShards = 64
+
+func newEntropyShards(procs int) *entropyShards {
+	n := 1
+	for n < procs && n < maxEntropyShards {
+		n <<= 1
+	}
+	s := &entropyShards{pools: make([]entropyPool, n)}
+	for i := range s.pools {
+		s.pools[i].off = entropyPoolSize
+	}
+	return s
+}
+
+// Read fills b with random bytes. It either fills all of b or returns an error.
+func (s *entropyShards) Read(b []byte) (int, error) {
+	p := s.lock()
+	defer p.mu.Unlock()
+	return p.read(b)
+}
+
+// lock locks and returns a pool picked at random, moving on to the next one while pools
+// are busy. Only when all of them are busy does it wait, for the one picked first.
+func (s *entropyShards) lock() *entropyPool {
+	mask := len(s.pools) - 1
+	i := 0
+	if mask > 0 {
+		i = int(mathrand.Uint32()) & mask
+	}
+	for j := 0; j <= mask; j++ {
+		if p := &s.pools[(i+j)&mask]; p.mu.TryLock() {
+			return p
+		}
+	}
+	p := &s.pools[i]
+	p.mu.Lock()
+	return p
+}
+
+// read fills b with random bytes. The caller must hold p.mu.
+func (p *entropyPool) read(b []byte) (int, error) {
+	n := 0
+	for n < len(b) {
+		if p.off == entropyPoolSize {
+			if p.buf == nil {
+				p.buf = new([entropyPoolSize]byte)
+			}
+			if _, err := io.ReadFull(rand.Reader, p.buf[:]); err != nil {
+				return n, err
+			}
+			p.off = 0
+		}
+		c := copy(b[n:], p.buf[p.off:])
+		clear(p.buf[p.off : p.off+c])
+		p.off += c
+		n += c
+	}
+	return n, nil
+}
+
+// fallbackEntropy is the source of random bytes used with EntropyFallback. It is seeded
+// at init, while crypto/rand is still expected to work, rather than when it's first
+// needed, which is when crypto/rand fails. Programs built with the uuid_strict tag never
+// fall back and so don't seed it.
+var fallbackEntropy *fallbackPool
+
+// fallbackPool is a concurrency-safe ChaCha8 pseudo-random generator
+type fallbackPool struct {
+	mu sync.Mutex
+	r  *mathrand.ChaCha8
+}
+
+// newFallbackPool seeds a fallbackPool from crypto/rand, or if that fails, from the
+// runtime's random generator which is seeded by the operating system at startup
+func newFallbackPool() *fallbackPool {
+	var seed [32]byte
+	if _, err := io.ReadFull(rand.Reader, seed[:]); err != nil {
+		for i := 0; i < len(seed); i += 8 {
+			binary.LittleEndian.PutUint64(seed[i:], mathrand.Uint64())
+		}
+	}
+	return &fallbackPool{r: mathrand.NewChaCha8(seed)}
+}
+
+// Read fills b with pseudo-random bytes. It never fails.
+func (p *fallbackPool) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.r.Read(b)
+}
+
+// ErrStrictEntropy is returned when generating a UUID would use a source of random bytes
+// other than crypto/rand while strict entropy mode is enabled (see SetStrictEntropy)
+var ErrStrictEntropy = errors.New("uuid: only crypto/rand may be used in strict entropy mode")
+
+// strictEntropy is true when strict entropy mode is enabled
+var strictEntropy atomic.Bool
+
+func init() {
+	strictEntropy.Store(strictEntropyBuild)
+	if !strictEntropyBuild {
+		fallbackEntropy = newFallbackPool()
+	}
+}
+
+// SetStrictEntropy enables strict entropy mode for the rest of the life of the process,
+// for deployments whose audit requirements rule out pseudo-random fallbacks. It is also
+// enabled from the start in programs built with the uuid_strict build tag.
+//
+// In strict entropy mode, random bytes only ever come from crypto/rand and any failure to
+// read them is an error:
+//
+//   - EntropyFallback behaves like EntropyError, never using the pseudo-random fallback
+//   - GenFrom, and Generators with a Rand reader (including those of NewSeeded), return
+//     ErrStrictEntropy unless the reader is crypto/rand.Reader
+//
+// Combine this with the FIPS 140-3 mode of the Go standard library (GODEBUG=fips140=on)
+// to have crypto/rand use an approved random number generator.
+func SetStrictEntropy() {
+	strictEntropy.Store(true)
+}
+
+// StrictEntropy reports whether strict entropy mode is enabled (see SetStrictEntropy)
+func StrictEntropy() bool {
+	return strictEntropy.Load()
+}
+
+// checkStrictReader returns ErrStrictEntropy if r may not be read in strict entropy mode
+func checkStrictReader(r io.Reader) error {
+	if r != rand.Reader && strictEntropy.Load() {
+		return ErrStrictEntropy
+	}
+	return nil
+}
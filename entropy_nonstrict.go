@@ -0,0 +1,6 @@
+//go:build !uuid_strict
+
+package uuid
+
+// strictEntropyBuild enables strict entropy mode from the start (see SetStrictEntropy)
+const strictEntropyBuild = false
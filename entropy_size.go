@@ -0,0 +1,7 @@
+//go:build !tinygo && !uuid_smallpool
+
+package uuid
+
+// entropyPoolSize is the number of random bytes read from crypto/rand at a time.
+// Each UUID uses 8 bytes so this is enough for 512 UUIDs per read.
+const entropyPoolSize = 4096
@@ -0,0 +1,8 @@
+//go:build tinygo || uuid_smallpool
+
+package uuid
+
+// entropyPoolSize is the number of random bytes read from crypto/rand at a time.
+// This is the variant for TinyGo and memory-constrained targets (or when building with the
+// uuid_smallpool tag), where a 4 kB buffer is too much; it holds enough for 8 UUIDs.
+const entropyPoolSize = 64
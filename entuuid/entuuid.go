@@ -0,0 +1,57 @@
+// Package entuuid integrates github.com/rsms/go-uuid UUIDs with the ent entity framework,
+// entgo.io/ent.
+//
+// Use ID to declare the primary key of a schema, generated with uuid.Gen on creation:
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			entuuid.ID(),
+//			field.String("name"),
+//		}
+//	}
+//
+// Other UUID fields, or IDs needing further options, are declared with field.UUID:
+//
+//	field.UUID("group_id", entuuid.UUID{}).Default(entuuid.New).Unique()
+package entuuid
+
+import (
+	"database/sql/driver"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/rsms/go-uuid"
+)
+
+// UUID wraps uuid.UUID to implement the field.ValueScanner interface required by field.UUID
+type UUID uuid.UUID
+
+// New generates a new UUID with uuid.Gen. Panics if generation fails (see uuid.MustGen.)
+// Its signature matches what field.UUID Default expects.
+func New() UUID { return UUID(uuid.MustGen()) }
+
+// ID returns an immutable "id" field of type UUID with New as its default
+func ID() ent.Field {
+	return field.UUID("id", UUID{}).Default(New).Immutable()
+}
+
+// String returns the string representation of the UUID (see uuid.UUID.String)
+func (id UUID) String() string { return uuid.UUID(id).String() }
+
+// Value implements the driver.Valuer interface using the hyphenated hex form,
+// which matches the column types ent uses for UUIDs (e.g. uuid with PostgreSQL
+// and char(36) with MySQL) and preserves the sort order of UUIDs.
+func (id UUID) Value() (driver.Value, error) {
+	return uuid.UUID(id).HexHyphenated(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the formats of uuid.NullUUID.Scan.
+// NULL scans as the zero UUID.
+func (id *UUID) Scan(src any) error {
+	var n uuid.NullUUID
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*id = UUID(n.UUID)
+	return nil
+}
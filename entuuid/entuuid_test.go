@@ -0,0 +1,39 @@
+package entuuid
+
+import (
+	"testing"
+
+	"entgo.io/ent/schema/field"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func TestID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	d := ID().Descriptor()
+	assert.NoErr("Descriptor", d.Err)
+	assert.Eq("Name", d.Name, "id")
+	assert.Eq("Type", d.Info.Type, field.TypeUUID)
+	assert.Eq("Ident", d.Info.Ident, "entuuid.UUID")
+	assert.Ok("Immutable", d.Immutable)
+	def, ok := d.Default.(func() UUID)
+	assert.Ok("Default", ok)
+	assert.Ok("Default generates", def() != UUID{} && def() != def())
+}
+
+func TestValueScan(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustFromString("14dkqb8qQuruFQRFEEaUf")
+	v, err := UUID(id).Value()
+	assert.NoErr("Value", err)
+	assert.Eq("Value", v, "0091d2a5-0000-0000-52fd-fc072182654d")
+
+	var u UUID
+	assert.NoErr("Scan text", u.Scan(v))
+	assert.Eq("Scan text", uuid.UUID(u), id)
+	assert.NoErr("Scan bytes", u.Scan(id[:]))
+	assert.Eq("Scan bytes", uuid.UUID(u), id)
+	assert.NoErr("Scan NULL", u.Scan(nil))
+	assert.Eq("Scan NULL", u, UUID{})
+	assert.Err("Scan invalid", "cannot scan", u.Scan(3.5))
+}
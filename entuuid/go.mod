@@ -0,0 +1,17 @@
+module github.com/rsms/go-uuid/entuuid
+
+go 1.24.0
+
+require (
+	entgo.io/ent v0.14.6
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+)
+
+require (
+	github.com/kr/pretty v0.3.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/rogpeppe/go-internal v1.6.1 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
@@ -0,0 +1,565 @@
+package uuid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"sync"
+	"time"
+)
+
+// Generator generates UUIDs with a custom configuration.
+// The zero value is ready to use and generates UUIDs like Gen, except that it returns an
+// error when the clock is outside the range of timestamps representable by UUIDs, where
+// Gen would wrap around (see TimeRangePolicy.)
+// A Generator is safe for concurrent use and must not be copied after first use.
+type Generator struct {
+	// Epoch is the time which the timestamps of UUIDs are relative to.
+	// Only whole seconds are used. The zero value means the default epoch,
+	// 2020-09-13 12:26:40 UTC (Unix time 1600000000.)
+	//
+	// All operations involving the time of UUIDs generated with a custom epoch must use
+	// the methods of a Generator with the same epoch, e.g. Generator.Time rather than
+	// UUID.Time.
+	Epoch time.Time
+
+	// Layout determines how timestamps are stored in bytes 0-5 of UUIDs. The zero value,
+	// LayoutSeconds, is the layout of Gen. With LayoutUnixMilli, timestamps are always
+	// relative to the Unix epoch and Epoch must be zero.
+	//
+	// Like with Epoch, operations involving the time of UUIDs generated with another
+	// layout must use the methods of a Generator with the same Layout. UUIDs can be
+	// converted between layouts with ToUnixMilliLayout and FromUnixMilliLayout.
+	Layout Layout
+
+	// NodeIDLen is the number of bytes (0, 1 or 2) of NodeID to store in generated UUIDs.
+	// When non-zero, NodeID is stored in big-endian byte order in bytes 8-9 (or just byte 8)
+	// in place of random bytes, right after the timestamp.
+	//
+	// This guarantees that UUIDs generated by Generators with different node IDs never
+	// collide, for example across a fleet of hosts which are each assigned a unique ID.
+	// Uniqueness of UUIDs generated by the same node still relies on the remaining random
+	// bytes, which are fewer than usual.
+	NodeIDLen int
+
+	// NodeID identifies the node which UUIDs are generated by. See NodeIDLen.
+	NodeID uint16
+
+	// Sequence, when true, stores a counter in bytes 6-7 in place of the bits of the
+	// nanosecond timestamp. The counter starts at zero every millisecond and is incremented
+	// for every UUID generated by the Generator within the same millisecond.
+	//
+	// This guarantees that UUIDs generated by the same Generator are ordered by the time
+	// they were generated, regardless of the resolution of the system clock. If the counter
+	// would overflow (65536 UUIDs in one millisecond), Gen waits for the next millisecond.
+	Sequence bool
+
+	// Rand is the source of random bytes. If nil, the host system's random source
+	// (crypto/rand) is used.
+	Rand io.Reader
+
+	// EntropyPolicy determines what happens when reading random bytes fails.
+	// The default, EntropyError, makes Gen return the error.
+	EntropyPolicy EntropyPolicy
+
+	// OnEntropyFailure, if not nil, is called with the error whenever reading random bytes
+	// fails. It is called regardless of EntropyPolicy, for every failed attempt, making it
+	// possible to alert on degraded entropy even when failures are retried or replaced by
+	// the fallback. See also SetEntropyObserver.
+	OnEntropyFailure func(err error)
+
+	// Now returns the current time. If nil, time.Now is used.
+	Now func() time.Time
+
+	// ClockPolicy determines what happens when the clock goes backwards, e.g. when the
+	// system clock is adjusted by NTP. The default, ClockIgnore, uses the clock as-is,
+	// meaning that UUIDs generated after such an adjustment may sort before UUIDs generated
+	// before it.
+	ClockPolicy ClockPolicy
+
+	// TimeRangePolicy determines what happens when the time of a UUID is outside of the
+	// range representable with the Epoch and Layout of the Generator, for example when
+	// backfilling historical events with GenAt. The default, TimeRangeError, returns an
+	// error rather than generating a UUID with a wrapped-around timestamp.
+	TimeRangePolicy TimeRangePolicy
+
+	// OnClockBackwards, if not nil, is called with the timestamp of the last UUID generated
+	// and the current time whenever the clock is observed to have gone backwards.
+	// It is called regardless of ClockPolicy, before Gen returns.
+	OnClockBackwards func(last, now time.Time)
+
+	// Rate, if positive, limits the number of UUIDs generated per second, as a guardrail
+	// against runaway producers. Up to Burst UUIDs (at least one) may be generated at once
+	// after a period of inactivity. RatePolicy determines what happens when the rate is
+	// exceeded.
+	Rate  float64
+	Burst int
+
+	// RatePolicy determines what happens when generating a UUID would exceed Rate.
+	// The default, RateError, makes Gen return ErrRateLimited.
+	RatePolicy RatePolicy
+
+	mu     sync.Mutex
+	lastMs int64  // Unix time in milliseconds of the last UUID generated
+	lastLo uint16 // bytes 6-7 of the last UUID generated
+
+	rateMu     sync.Mutex
+	rateTokens float64   // UUIDs which may be generated right now; negative when reserved
+	rateLast   time.Time // time rateTokens was last updated; zero before first use
+}
+
+// ClockPolicy determines how a Generator handles the clock going backwards
+type ClockPolicy int
+
+const (
+	// ClockIgnore uses the clock as-is
+	ClockIgnore ClockPolicy = iota
+
+	// ClockWait makes Gen wait until the clock has caught up with the timestamp of the
+	// last UUID generated. This is suitable for small clock adjustments.
+	ClockWait
+
+	// ClockReuse keeps using the timestamp of the last UUID generated until the clock has
+	// caught up with it, with bytes 6-7 used as a counter to maintain order. This never
+	// blocks but the timestamps of UUIDs will be ahead of the clock until it has caught up.
+	ClockReuse
+)
+
+// EntropyPolicy determines how a Generator handles failure of its source of random bytes
+type EntropyPolicy int
+
+const (
+	// EntropyError makes Gen return the error
+	EntropyError EntropyPolicy = iota
+
+	// EntropyRetry retries reading a few times, waiting a little longer between every
+	// attempt, before returning the error. This rides out transient failures.
+	EntropyRetry
+
+	// EntropyPanic makes Gen panic with the error
+	EntropyPanic
+
+	// EntropyFallback uses a ChaCha8 pseudo-random generator which is seeded once, when the
+	// package is initialized, from crypto/rand (or if that fails, from the Go runtime's
+	// random seed, which is provided by the operating system.) This keeps generating
+	// unpredictable UUIDs when the random source fails, but makes their quality depend on
+	// that seed.
+	// In strict entropy mode (see SetStrictEntropy) this is the same as EntropyError.
+	EntropyFallback
+)
+
+// TimeRangePolicy determines how a Generator handles times outside of the range of
+// timestamps it can represent
+type TimeRangePolicy int
+
+const (
+	// TimeRangeError returns an error
+	TimeRangeError TimeRangePolicy = iota
+
+	// TimeRangeClamp uses the earliest or latest representable time instead, so that UUIDs
+	// of times before the range sort first and those of times after it sort last
+	TimeRangeClamp
+)
+
+// RatePolicy determines how a Generator handles generating UUIDs faster than its Rate
+type RatePolicy int
+
+const (
+	// RateError makes Gen return ErrRateLimited
+	RateError RatePolicy = iota
+
+	// RateWait makes Gen wait until a UUID may be generated
+	RateWait
+)
+
+// ErrRateLimited is returned when generating a UUID would exceed the Rate of a Generator
+// with RatePolicy RateError
+var ErrRateLimited = errors.New("uuid: generation rate limit exceeded")
+
+// entropyRetries is the number of attempts made with EntropyRetry
+const entropyRetries = 4
+
+// epoch returns the Unix time of the epoch in seconds
+func (g *Generator) epoch() int64 {
+	if g.Epoch.IsZero() {
+		return idEpochBase
+	}
+	return g.Epoch.Unix()
+}
+
+func (g *Generator) now() time.Time {
+	if g.Now != nil {
+		return g.Now()
+	}
+	return time.Now()
+}
+
+// Gen generates a universally unique UUID suitable to be used for sorted identity.
+// See the package function Gen for details.
+func (g *Generator) Gen() (UUID, error) {
+	if err := g.takeRate(); err != nil {
+		return UUID{}, err
+	}
+	var id UUID
+	random, err := g.prepare(&id)
+	if err == nil {
+		err = g.readRandom(random)
+	}
+	if err != nil {
+		g.returnRate()
+		return id, err
+	}
+	stats.generated.Add(1)
+	return id, nil
+}
+
+// Stream returns an iterator which yields UUIDs generated by g until ctx is done or the
+// loop is stopped. Random bytes are read in batches, making this more efficient than
+// calling Gen repeatedly. If generating a UUID fails, the error is yielded (with a zero
+// UUID) and the iteration ends. For example:
+//
+//	for id, err := range g.Stream(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (g *Generator) Stream(ctx context.Context) iter.Seq2[UUID, error] {
+	return func(yield func(UUID, error) bool) {
+		var buf [streamBatchSize * 8]byte
+		var avail []byte // unused bytes of buf
+		defer clear(buf[:])
+		for ctx.Err() == nil {
+			if err := g.takeRate(); err != nil {
+				yield(UUID{}, err)
+				return
+			}
+			var id UUID
+			random, err := g.prepare(&id)
+			if err == nil && len(avail) < len(random) {
+				err = g.readRandom(buf[:])
+				avail = buf[:]
+			}
+			if err != nil {
+				g.returnRate()
+				yield(UUID{}, err)
+				return
+			}
+			n := copy(random, avail)
+			clear(avail[:n])
+			avail = avail[n:]
+			stats.generated.Add(1)
+			if !yield(id, nil) {
+				return
+			}
+		}
+	}
+}
+
+// streamBatchSize is the number of UUIDs worth of random bytes Stream reads at once
+const streamBatchSize = 64
+
+// prepare sets the timestamp and node ID of id, returning the part of id which is to be
+// filled with random bytes
+func (g *Generator) prepare(id *UUID) ([]byte, error) {
+	ms, lo := g.timestamp()
+	return g.prepareAt(id, ms, lo)
+}
+
+// prepareAt is like prepare but with the Unix time ms in milliseconds and the value lo of
+// bytes 6-7 given
+func (g *Generator) prepareAt(id *UUID, ms int64, lo uint16) ([]byte, error) {
+	// a reader rejected in strict mode is a configuration error, not an entropy failure
+	if g.Rand != nil {
+		if err := checkStrictReader(g.Rand); err != nil {
+			return nil, err
+		}
+	}
+	ms, err := g.checkRange(ms)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.setLayoutTime(id, ms); err != nil {
+		return nil, err
+	}
+	id[6] = byte(lo >> 8)
+	id[7] = byte(lo)
+	switch g.NodeIDLen {
+	case 0:
+		return id[8:], nil
+	case 1:
+		if g.NodeID > 0xff {
+			return nil, fmt.Errorf("uuid: NodeID %d does not fit in NodeIDLen 1", g.NodeID)
+		}
+		id[8] = byte(g.NodeID)
+		return id[9:], nil
+	case 2:
+		id[8] = byte(g.NodeID >> 8)
+		id[9] = byte(g.NodeID)
+		return id[10:], nil
+	}
+	return nil, fmt.Errorf("uuid: invalid NodeIDLen %d", g.NodeIDLen)
+}
+
+// readRandom fills b with random bytes from g.Rand or the host system's random source,
+// handling failure according to g.EntropyPolicy
+func (g *Generator) readRandom(b []byte) error {
+	err := g.read(b)
+	if err == nil {
+		return nil
+	}
+	switch g.EntropyPolicy {
+	case EntropyRetry:
+		for i := 1; i < entropyRetries && err != nil; i++ {
+			time.Sleep(time.Duration(i) * time.Millisecond)
+			err = g.read(b)
+		}
+	case EntropyPanic:
+		panic(err)
+	case EntropyFallback:
+		if strictEntropy.Load() {
+			break
+		}
+		stats.entropyFallbacks.Add(1)
+		fallbackEntropy.Read(b)
+		return nil
+	}
+	return err
+}
+
+func (g *Generator) read(b []byte) error {
+	var err error
+	if g.Rand != nil {
+		_, err = io.ReadFull(g.Rand, b)
+	} else {
+		_, err = entropy.Read(b)
+	}
+	if err != nil {
+		entropyFailed(err)
+		if g.OnEntropyFailure != nil {
+			g.OnEntropyFailure(err)
+		}
+	}
+	return err
+}
+
+// timestamp returns the Unix time in milliseconds and the value of bytes 6-7 (bits of the
+// nanosecond timestamp or a sequence number) for the next UUID to be generated
+func (g *Generator) timestamp() (ms int64, lo uint16) {
+	if !g.Sequence && g.ClockPolicy == ClockIgnore && g.OnClockBackwards == nil {
+		t := g.now()
+		return unixMilli(t), uint16(t.Nanosecond() >> 16)
+	}
+
+	var lastMs, observedMs int64
+	backwards := false
+	defer func() {
+		// called without holding the lock so that the callback may use the Generator
+		if backwards && g.OnClockBackwards != nil {
+			g.OnClockBackwards(msTime(lastMs), msTime(observedMs))
+		}
+	}()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	t := g.now()
+	ms = unixMilli(t)
+	if !g.Sequence {
+		lo = uint16(t.Nanosecond() >> 16)
+	}
+	if ms < g.lastMs {
+		backwards, lastMs, observedMs = true, g.lastMs, ms
+		switch g.ClockPolicy {
+		case ClockWait:
+			for ms < g.lastMs {
+				time.Sleep(time.Duration(g.lastMs-ms) * time.Millisecond)
+				t = g.now()
+				ms = unixMilli(t)
+			}
+			if !g.Sequence {
+				lo = uint16(t.Nanosecond() >> 16)
+			}
+		case ClockReuse:
+			// continue from the last timestamp, counting up so that order is maintained
+			ms = g.lastMs
+			if g.lastLo == 0xffff {
+				ms++
+				lo = 0
+			} else {
+				lo = g.lastLo + 1
+			}
+			g.lastMs, g.lastLo = ms, lo
+			return
+		}
+	}
+	if g.Sequence && ms == g.lastMs {
+		if g.lastLo == 0xffff {
+			// sequence exhausted; wait for the next millisecond
+			for ms <= g.lastMs {
+				time.Sleep(10 * time.Microsecond)
+				ms = unixMilli(g.now())
+			}
+		} else {
+			lo = g.lastLo + 1
+		}
+	}
+	g.lastMs, g.lastLo = ms, lo
+	return
+}
+
+// msTime returns the time.Time of the Unix time ms in milliseconds
+func msTime(ms int64) time.Time {
+	return time.Unix(floorDiv(ms, 1000), floorMod(ms, 1000)*int64(time.Millisecond))
+}
+
+func floorDiv(a, b int64) int64 {
+	if a < 0 && a%b != 0 {
+		return a/b - 1
+	}
+	return a / b
+}
+
+func floorMod(a, b int64) int64 {
+	return a - floorDiv(a, b)*b
+}
+
+// unixMilli returns t as a Unix time in milliseconds
+func unixMilli(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
+
+// GenAt is like Gen but uses t as the timestamp instead of the current time, for example
+// when backfilling historical events. Sequence and ClockPolicy don't apply; bytes 6-7
+// hold bits of the nanosecond part of t. Times outside of the range representable by g
+// are handled according to g.TimeRangePolicy.
+func (g *Generator) GenAt(t time.Time) (UUID, error) {
+	if err := g.takeRate(); err != nil {
+		return UUID{}, err
+	}
+	var id UUID
+	random, err := g.prepareAt(&id, unixMilli(t), uint16(t.Nanosecond()>>16))
+	if err == nil {
+		err = g.readRandom(random)
+	}
+	if err != nil {
+		g.returnRate()
+		return id, err
+	}
+	stats.generated.Add(1)
+	return id, nil
+}
+
+// NewFromTime creates a new UUID with the timestamp t and random bytes, like the package
+// function NewFromTime but using the Epoch, Layout and TimeRangePolicy of g.
+// Up to 10 bytes are used from random.
+func (g *Generator) NewFromTime(t time.Time, random []byte) (UUID, error) {
+	var id UUID
+	ms, err := g.checkRange(unixMilli(t))
+	if err == nil {
+		err = g.setLayoutTime(&id, ms)
+	}
+	if err != nil {
+		return UUID{}, err
+	}
+	copy(id[6:], random)
+	return id, nil
+}
+
+// takeRate takes a token from the token bucket implementing Rate, if any. When none is
+// available, it returns ErrRateLimited or, with RateWait, reserves the next token and
+// waits for it to become available according to the clock of g. The wait happens without
+// holding a lock, so concurrent callers reserve tokens in turn and wait in parallel.
+func (g *Generator) takeRate() error {
+	if g.Rate <= 0 {
+		return nil
+	}
+	g.rateMu.Lock()
+	now := g.now()
+	burst := float64(max(g.Burst, 1))
+	if g.rateLast.IsZero() {
+		g.rateTokens = burst
+	} else if elapsed := now.Sub(g.rateLast); elapsed > 0 {
+		g.rateTokens = min(burst, g.rateTokens+elapsed.Seconds()*g.Rate)
+	}
+	g.rateLast = now
+	if g.rateTokens < 1 && g.RatePolicy != RateWait {
+		g.rateMu.Unlock()
+		return ErrRateLimited
+	}
+	g.rateTokens-- // may go negative, reserving a token which isn't available yet
+	wait := time.Duration(-g.rateTokens / g.Rate * float64(time.Second))
+	g.rateMu.Unlock()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return nil
+}
+
+// returnRate returns the token taken by takeRate when generating a UUID failed, so that
+// failures don't use up the Rate of g
+func (g *Generator) returnRate() {
+	if g.Rate <= 0 {
+		return
+	}
+	g.rateMu.Lock()
+	g.rateTokens = min(float64(max(g.Burst, 1)), g.rateTokens+1)
+	g.rateMu.Unlock()
+}
+
+// msRange returns the range of Unix times in milliseconds representable by g
+func (g *Generator) msRange() (lo, hi int64) {
+	if g.Layout == LayoutUnixMilli {
+		return 0, maxUnixMilli
+	}
+	epoch := g.epoch()
+	return epoch * 1000, (epoch+math.MaxUint32)*1000 + 999
+}
+
+// checkRange handles the Unix time ms in milliseconds according to g.TimeRangePolicy if it
+// is outside of g.msRange()
+func (g *Generator) checkRange(ms int64) (int64, error) {
+	lo, hi := g.msRange()
+	if ms >= lo && ms <= hi {
+		return ms, nil
+	}
+	if g.TimeRangePolicy == TimeRangeClamp {
+		return max(lo, min(ms, hi)), nil
+	}
+	return ms, &OutOfRangeError{Time: msTime(ms)}
+}
+
+// New creates a new UUID with specific Unix timestamp and random bytes.
+// See the package function New for details.
+func (g *Generator) New(sec int64, nsec int, random []byte) UUID {
+	if g.Layout == LayoutUnixMilli {
+		var id UUID
+		putUnixMilli(&id, sec*1000+int64(nsec/int(time.Millisecond)))
+		copy(id[6:], random)
+		return id
+	}
+	return newID(g.epoch(), sec, nsec, random)
+}
+
+// Node returns the node ID stored in id, which is expected to have been generated by
+// a Generator with the same NodeIDLen as g. Returns 0 if g.NodeIDLen is 0.
+func (g *Generator) Node(id UUID) uint16 {
+	switch g.NodeIDLen {
+	case 1:
+		return uint16(id[8])
+	case 2:
+		return uint16(id[8])<<8 | uint16(id[9])
+	}
+	return 0
+}
+
+// Time returns the time portion of id, which is expected to have been created by
+// a Generator with the same epoch and Layout as g
+func (g *Generator) Time(id UUID) time.Time {
+	if g.Layout == LayoutUnixMilli {
+		return msTime(unixMilliOf(id))
+	}
+	return id.timeWithEpoch(g.epoch())
+}
@@ -0,0 +1,415 @@
+package uuid
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestGeneratorEpoch(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// zero value behaves like the package functions
+	var g0 Generator
+	tm := time.Unix(1603212345, 713*int64(time.Millisecond))
+	assert.Eq("zero Generator New", g0.New(tm.Unix(), tm.Nanosecond(), []byte{1}),
+		New(tm.Unix(), tm.Nanosecond(), []byte{1}))
+	id, err := g0.Gen()
+	assert.NoErr("zero Generator Gen", err)
+	assert.Ok("zero Generator Gen time", time.Since(id.Time()) < time.Minute)
+
+	// custom epoch
+	g := Generator{Epoch: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)}
+	id = g.New(tm.Unix(), tm.Nanosecond(), nil)
+	sec, ms := id.Timestamp()
+	assert.Eq("custom epoch seconds", int64(sec), tm.Unix()-g.Epoch.Unix())
+	assert.Eq("custom epoch milliseconds", ms, uint16(713))
+	assert.Eq("custom epoch Time", g.Time(id).UnixNano(), tm.UnixNano())
+
+	// times before the default epoch can be represented with an earlier epoch
+	old := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	id = g.New(old.Unix(), 0, nil)
+	assert.Eq("Time before default epoch", g.Time(id).UTC(), old)
+
+	id, err = g.Gen()
+	assert.NoErr("custom epoch Gen", err)
+	assert.Ok("custom epoch Gen time", time.Since(g.Time(id)) < time.Minute)
+	assert.Ok("custom epoch Gen differs from default epoch", id.Time().After(time.Now().Add(time.Hour)))
+}
+
+func TestGeneratorNodeID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	g1 := Generator{NodeIDLen: 2, NodeID: 0x1234}
+	g2 := Generator{NodeIDLen: 1, NodeID: 0xab}
+	id1, err := g1.Gen()
+	assert.NoErr("Gen", err)
+	assert.Eq("node ID bytes", id1[8:10], []byte{0x12, 0x34})
+	assert.Eq("Node", g1.Node(id1), uint16(0x1234))
+	assert.Ok("Gen time", time.Since(id1.Time()) < time.Minute)
+
+	id2, err := g2.Gen()
+	assert.NoErr("Gen", err)
+	assert.Eq("node ID byte", id2[8], byte(0xab))
+	assert.Eq("Node", g2.Node(id2), uint16(0xab))
+	assert.Eq("Node without NodeIDLen", (&Generator{}).Node(id2), uint16(0))
+
+	_, err = (&Generator{NodeIDLen: 1, NodeID: 0x100}).Gen()
+	assert.Err("NodeID too large", "does not fit", err)
+	_, err = (&Generator{NodeIDLen: 3}).Gen()
+	assert.Err("invalid NodeIDLen", "invalid NodeIDLen 3", err)
+}
+
+func TestGeneratorSequence(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	g := &Generator{Sequence: true}
+	prev, err := g.Gen()
+	assert.NoErr("Gen", err)
+	for i := 0; i < 10000; i++ {
+		id, err := g.Gen()
+		assert.NoErr("Gen", err)
+		if !assert.Ok("ordered", string(id[:8]) > string(prev[:8]), "%x <= %x", id[:8], prev[:8]) {
+			break
+		}
+		if string(id[:6]) != string(prev[:6]) {
+			assert.Eq("sequence restarts at zero", id[6:8], []byte{0, 0})
+		} else {
+			seq := int(id[6])<<8 | int(id[7])
+			prevSeq := int(prev[6])<<8 | int(prev[7])
+			assert.Eq("sequence increments", seq, prevSeq+1)
+		}
+		prev = id
+	}
+}
+
+func TestGeneratorClockBackwards(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// a clock which can be moved by the test
+	var clock time.Time
+	var reported []time.Time
+	newGen := func(policy ClockPolicy) *Generator {
+		clock = time.Unix(1603212345, 500*int64(time.Millisecond))
+		reported = nil
+		return &Generator{
+			ClockPolicy: policy,
+			Now:         func() time.Time { return clock },
+			OnClockBackwards: func(last, now time.Time) {
+				reported = append(reported, last, now)
+			},
+		}
+	}
+	less := func(a, b UUID) bool { return string(a[:8]) < string(b[:8]) }
+
+	// ClockIgnore: order is not maintained but the event is reported
+	g := newGen(ClockIgnore)
+	id1, _ := g.Gen()
+	clock = clock.Add(-time.Second)
+	id2, _ := g.Gen()
+	assert.Ok("ClockIgnore sorts before", less(id2, id1))
+	assert.Eq("reported", len(reported), 2)
+	assert.Eq("reported last", reported[0], time.Unix(1603212345, 500*int64(time.Millisecond)))
+	assert.Eq("reported now", reported[1], time.Unix(1603212344, 500*int64(time.Millisecond)))
+
+	// ClockReuse: the timestamp of the last UUID is reused with a counter
+	g = newGen(ClockReuse)
+	id1, _ = g.Gen()
+	clock = clock.Add(-time.Second)
+	id2, _ = g.Gen()
+	id3, _ := g.Gen()
+	assert.Ok("ClockReuse maintains order", less(id1, id2) && less(id2, id3))
+	assert.Eq("ClockReuse reuses timestamp", id2.Time(), id1.Time())
+	assert.Eq("reported twice", len(reported), 4)
+	clock = clock.Add(2 * time.Second) // clock catches up
+	id4, _ := g.Gen()
+	assert.Ok("ClockReuse after catching up", less(id3, id4))
+	assert.Eq("ClockReuse uses clock after catching up", id4.Time(), clock)
+
+	// ClockWait: waits for the clock to catch up
+	g = newGen(ClockWait)
+	id1, _ = g.Gen()
+	start := clock
+	clock = clock.Add(-5 * time.Millisecond)
+	g.Now = func() time.Time {
+		// advance the clock 1ms every time it is read
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+	id2, _ = g.Gen()
+	assert.Ok("ClockWait maintains order", !less(id2, id1))
+	assert.Ok("ClockWait waited for the clock", !id2.Time().Before(start))
+	assert.Eq("reported", len(reported), 2)
+}
+
+func TestGeneratorStream(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	var g Generator
+	seen := make(map[UUID]bool)
+	for id, err := range g.Stream(context.Background()) {
+		assert.NoErr("Stream", err)
+		assert.Ok("unique", !seen[id])
+		assert.Ok("time", time.Since(id.Time()) < time.Minute)
+		seen[id] = true
+		if len(seen) == streamBatchSize*3 {
+			break
+		}
+	}
+	assert.Eq("count", len(seen), streamBatchSize*3)
+
+	// same sequence as Gen with the same seeded source
+	start := time.Unix(1603212345, 0)
+	g1, g2 := NewSeeded(1, start), NewSeeded(1, start)
+	n := 0
+	for id, err := range g1.Stream(context.Background()) {
+		assert.NoErr("Stream", err)
+		id2, _ := g2.Gen()
+		assert.Eq("seeded Stream", id, id2)
+		if n++; n == streamBatchSize+1 {
+			break
+		}
+	}
+
+	// stops when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n = 0
+	for range g.Stream(ctx) {
+		if n++; n == 3 {
+			cancel()
+		}
+	}
+	assert.Eq("stopped by context", n, 3)
+
+	// errors are yielded
+	g3 := Generator{Rand: bytes.NewReader(nil)}
+	n = 0
+	for _, err := range g3.Stream(context.Background()) {
+		assert.Err("Stream error", "EOF", err)
+		n++
+	}
+	assert.Eq("one error", n, 1)
+}
+
+// flakyReader fails the first n reads and then returns zero bytes
+type flakyReader struct{ n int }
+
+func (r *flakyReader) Read(b []byte) (int, error) {
+	if r.n > 0 {
+		r.n--
+		return 0, errors.New("entropy unavailable")
+	}
+	clear(b)
+	return len(b), nil
+}
+
+func TestGeneratorEntropyPolicy(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	g := &Generator{Rand: &flakyReader{n: 1}}
+	_, err := g.Gen()
+	assert.Err("EntropyError", "entropy unavailable", err)
+
+	g = &Generator{Rand: &flakyReader{n: entropyRetries - 1}, EntropyPolicy: EntropyRetry}
+	id, err := g.Gen()
+	assert.NoErr("EntropyRetry", err)
+	assert.Eq("EntropyRetry random bytes", id[8:], make([]byte, 8))
+	g = &Generator{Rand: &flakyReader{n: entropyRetries}, EntropyPolicy: EntropyRetry}
+	_, err = g.Gen()
+	assert.Err("EntropyRetry exhausted", "entropy unavailable", err)
+
+	g = &Generator{Rand: &flakyReader{n: 1}, EntropyPolicy: EntropyPanic}
+	assert.Panic("entropy unavailable", func() { g.Gen() })
+
+	g = &Generator{Rand: &flakyReader{n: 1000}, EntropyPolicy: EntropyFallback}
+	id, err = g.Gen()
+	assert.NoErr("EntropyFallback", err)
+	id2, err := g.Gen()
+	assert.NoErr("EntropyFallback", err)
+	assert.Ok("EntropyFallback random bytes", string(id[8:]) != string(id2[8:]))
+}
+
+// skipInStrictBuild skips tests which give Generators readers other than crypto/rand,
+// which uuid_strict builds reject
+func skipInStrictBuild(t *testing.T) {
+	if strictEntropyBuild {
+		t.Skip("uses a reader other than crypto/rand, rejected in uuid_strict builds")
+	}
+}
+
+func TestStrictEntropy(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	assert.Eq("enabled by default in strict builds only", StrictEntropy(), strictEntropyBuild)
+	SetStrictEntropy()
+	defer strictEntropy.Store(strictEntropyBuild)
+	assert.Ok("enabled", StrictEntropy())
+
+	_, err := Gen()
+	assert.NoErr("Gen", err)
+	_, err = GenFrom(rand.Reader)
+	assert.NoErr("GenFrom crypto/rand", err)
+	_, err = GenFrom(bytes.NewReader(make([]byte, 8)))
+	assert.Ok("GenFrom other reader", errors.Is(err, ErrStrictEntropy))
+
+	_, err = (&Generator{}).Gen()
+	assert.NoErr("Generator", err)
+	_, err = (&Generator{Rand: rand.Reader}).Gen()
+	assert.NoErr("Generator with crypto/rand", err)
+	_, err = NewSeeded(1, time.Now()).Gen()
+	assert.Ok("NewSeeded", errors.Is(err, ErrStrictEntropy))
+
+	// rejected readers are neither retried nor reported as entropy failures
+	failures := ReadStats().EntropyFailures
+	for _, policy := range []EntropyPolicy{EntropyError, EntropyRetry, EntropyPanic, EntropyFallback} {
+		called := false
+		r := &flakyReader{n: 1}
+		g := &Generator{
+			Rand:             r,
+			EntropyPolicy:    policy,
+			OnEntropyFailure: func(error) { called = true },
+		}
+		_, err = g.Gen()
+		assert.Ok("policy %d ErrStrictEntropy", errors.Is(err, ErrStrictEntropy), policy)
+		assert.Ok("policy %d OnEntropyFailure not called", !called, policy)
+		assert.Eq("policy %d reader not read", r.n, 1, policy)
+		_, err = g.GenAt(time.Now())
+		assert.Ok("policy %d GenAt ErrStrictEntropy", errors.Is(err, ErrStrictEntropy), policy)
+	}
+	assert.Eq("no entropy failures", ReadStats().EntropyFailures, failures)
+
+	// no fallback
+	before := ReadStats().EntropyFallbacks
+	restore := replaceEntropySource(failingReader{})
+	_, err = (&Generator{EntropyPolicy: EntropyFallback}).Gen()
+	restore()
+	assert.Err("EntropyFallback", "entropy unavailable", err)
+	assert.Eq("no fallback", ReadStats().EntropyFallbacks, before)
+}
+
+func TestGeneratorTimeRangePolicy(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	before := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	after := time.Date(2200, 1, 2, 3, 4, 5, 0, time.UTC)
+	minTime := Min.Time().UTC()
+	maxTime := Max.WithTime(time.Unix(idEpochBase+math.MaxUint32, 999e6)).Time().UTC()
+
+	var g Generator
+	_, err := g.GenAt(before)
+	assert.Err("GenAt before", "uuid: time 2019-01-02T03:04:05Z out of range", err)
+	_, err = g.GenAt(after)
+	assert.Err("GenAt after", "uuid: time 2200-01-02T03:04:05Z out of range", err)
+	_, err = g.NewFromTime(after, nil)
+	assert.Err("NewFromTime after", "out of range", err)
+	g.Now = func() time.Time { return before }
+	_, err = g.Gen()
+	assert.Err("Gen with clock out of range", "out of range", err)
+
+	g = Generator{TimeRangePolicy: TimeRangeClamp}
+	id, err := g.GenAt(before)
+	assert.NoErr("GenAt before", err)
+	assert.Eq("clamped to start", id.Time().UTC(), minTime)
+	id, err = g.GenAt(after)
+	assert.NoErr("GenAt after", err)
+	assert.Eq("clamped to end", id.Time().UTC(), maxTime)
+	id, err = g.NewFromTime(before, []byte{1, 2, 3})
+	assert.NoErr("NewFromTime", err)
+	assert.Eq("NewFromTime", id, UUID{6: 1, 7: 2, 8: 3})
+
+	// within range GenAt matches the package function
+	now := time.Now()
+	id, err = g.GenAt(now)
+	assert.NoErr("GenAt", err)
+	id2, _ := GenAt(now)
+	assert.Eq("GenAt", id[:8], id2[:8])
+
+	// the range depends on Epoch and Layout
+	g = Generator{Epoch: time.Unix(1000000000, 0)}
+	id, err = g.GenAt(before)
+	assert.NoErr("GenAt with Epoch", err)
+	assert.Eq("GenAt with Epoch", g.Time(id).UTC(), before)
+	g = Generator{Layout: LayoutUnixMilli, TimeRangePolicy: TimeRangeClamp}
+	id, err = g.GenAt(time.Unix(-5, 0))
+	assert.NoErr("GenAt LayoutUnixMilli", err)
+	assert.Eq("clamped to Unix epoch", g.Time(id).UTC(), time.Unix(0, 0).UTC())
+}
+
+func TestGeneratorRate(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	now := time.Unix(1700000000, 0)
+	g := &Generator{Rate: 10, Burst: 3, Now: func() time.Time { return now }}
+	for i := 0; i < 3; i++ {
+		_, err := g.Gen()
+		assert.NoErr("burst", err)
+	}
+	_, err := g.Gen()
+	assert.Ok("rate exceeded", errors.Is(err, ErrRateLimited))
+
+	now = now.Add(100 * time.Millisecond) // one more token
+	_, err = g.Gen()
+	assert.NoErr("refilled", err)
+	_, err = g.Gen()
+	assert.Ok("rate exceeded again", errors.Is(err, ErrRateLimited))
+
+	now = now.Add(time.Hour) // refills up to Burst only
+	for i := 0; i < 3; i++ {
+		_, err := g.GenAt(now)
+		assert.NoErr("GenAt burst", err)
+	}
+	_, err = g.GenAt(now)
+	assert.Ok("GenAt rate exceeded", errors.Is(err, ErrRateLimited))
+
+	// RateWait blocks instead
+	g = &Generator{Rate: 1000, RatePolicy: RateWait}
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		_, err := g.Gen()
+		assert.NoErr("RateWait", err)
+	}
+	assert.Ok("RateWait waited", time.Since(start) >= 9*time.Millisecond)
+
+	// RateWait computes waits with the clock of the Generator, even one which stands still
+	g = &Generator{Rate: 1000, RatePolicy: RateWait, Now: func() time.Time { return now }}
+	start = time.Now()
+	for i := 0; i < 4; i++ {
+		_, err := g.Gen()
+		assert.NoErr("RateWait with fixed clock", err)
+	}
+	assert.Ok("RateWait with fixed clock waited", time.Since(start) >= 6*time.Millisecond)
+
+	// waiting doesn't hold the lock of the token bucket
+	g = &Generator{Rate: 10, Burst: 1, RatePolicy: RateWait}
+	g.Gen()
+	done := make(chan struct{})
+	go func() {
+		g.Gen() // waits for about 100ms
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	locked := g.rateMu.TryLock()
+	assert.Ok("not locked while waiting", locked)
+	if locked {
+		g.rateMu.Unlock()
+	}
+	<-done
+
+	// failures don't use up the rate
+	g = &Generator{Rate: 10, Burst: 1, NodeIDLen: 3, Now: func() time.Time { return now }}
+	for i := 0; i < 3; i++ {
+		_, err := g.Gen()
+		assert.Err("invalid NodeIDLen", "invalid NodeIDLen 3", err)
+	}
+	g.NodeIDLen = 0
+	_, err = g.Gen()
+	assert.NoErr("token left after failures", err)
+	_, err = g.Gen()
+	assert.Ok("rate exceeded after failures", errors.Is(err, ErrRateLimited))
+}
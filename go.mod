@@ -1,5 +1,10 @@
 module github.com/rsms/go-uuid
 
-go 1.15
+go 1.23.0
 
 require github.com/rsms/go-testutil v0.1.1
+
+require (
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+)
@@ -0,0 +1,121 @@
+// Package gormuuid integrates github.com/rsms/go-uuid UUIDs with the GORM ORM, gorm.io/gorm.
+//
+// Declare UUID fields with the UUID type of this package, e.g.
+//
+//	type User struct {
+//		ID   gormuuid.UUID `gorm:"primaryKey"`
+//		Name string
+//	}
+//
+// UUIDs are stored in the native uuid type with PostgreSQL and as 16 bytes with other
+// databases (binary(16) with MySQL and SQL Server, blob with SQLite), keeping the sort
+// order of UUIDs in all of them.
+//
+// To generate primary keys when records are created, register the callback of this
+// package with the database:
+//
+//	db, err := gorm.Open(dialector, &gorm.Config{})
+//	...
+//	err = gormuuid.Register(db)
+package gormuuid
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/rsms/go-uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// UUID wraps uuid.UUID to implement sql.Scanner, driver.Valuer and the GORM data type
+// interfaces
+type UUID uuid.UUID
+
+// String returns the string representation of the UUID (see uuid.UUID.String)
+func (id UUID) String() string { return uuid.UUID(id).String() }
+
+// GormDataType implements the schema.GormDataTypeInterface interface
+func (UUID) GormDataType() string { return "uuid" }
+
+// GormDBDataType returns the column type for the database of db
+func (UUID) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "uuid"
+	case "sqlite":
+		return "blob"
+	}
+	return "binary(16)"
+}
+
+// GormValue implements the gorm.Valuer interface, encoding the UUID for the database of db
+func (id UUID) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() == "postgres" {
+		// the hyphenated hex form, which PostgreSQL accepts for the uuid type
+		return clause.Expr{SQL: "?", Vars: []any{uuid.UUID(id).HexHyphenated()}}
+	}
+	return clause.Expr{SQL: "?", Vars: []any{id[:]}}
+}
+
+// Value implements the driver.Valuer interface, storing the 16 bytes of the UUID
+func (id UUID) Value() (driver.Value, error) {
+	return uuid.UUID(id).Value()
+}
+
+// Scan implements the sql.Scanner interface, accepting the formats of uuid.NullUUID.Scan
+// which include the 16 bytes of a binary column and the text of a PostgreSQL uuid.
+// NULL scans as the zero UUID.
+func (id *UUID) Scan(src any) error {
+	var n uuid.NullUUID
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*id = UUID(n.UUID)
+	return nil
+}
+
+var uuidType = reflect.TypeOf(UUID{})
+
+// Register registers a callback with db which generates a new UUID with uuid.Gen for every
+// primary key field of type UUID which is zero when a record is created
+func Register(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("gormuuid:gen", genPrimaryKeys)
+}
+
+func genPrimaryKeys(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	rv := db.Statement.ReflectValue
+	for _, field := range db.Statement.Schema.PrimaryFields {
+		if field.FieldType != uuidType {
+			continue
+		}
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				genField(db, field, reflect.Indirect(rv.Index(i)))
+			}
+		case reflect.Struct:
+			genField(db, field, rv)
+		}
+	}
+}
+
+func genField(db *gorm.DB, field *schema.Field, rv reflect.Value) {
+	ctx := db.Statement.Context
+	if _, zero := field.ValueOf(ctx, rv); !zero {
+		return
+	}
+	id, err := uuid.Gen()
+	if err == nil {
+		err = field.Set(ctx, rv, UUID(id))
+	}
+	if err != nil {
+		db.AddError(fmt.Errorf("uuid: generating %s: %w", field.Name, err))
+	}
+}
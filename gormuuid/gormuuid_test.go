@@ -0,0 +1,83 @@
+package gormuuid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type user struct {
+	ID    UUID `gorm:"primaryKey"`
+	Name  string
+	Group UUID
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&user{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestGORM(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	db := openDB(t)
+
+	group := UUID(uuid.MustGen())
+	u := user{Name: "alice", Group: group}
+	assert.NoErr("Create", db.Create(&u).Error)
+	assert.Ok("ID generated", u.ID != UUID{})
+
+	// explicit IDs are kept
+	bobID := UUID(uuid.MustGen())
+	bob := user{ID: bobID, Name: "bob"}
+	assert.NoErr("Create", db.Create(&bob).Error)
+	assert.Eq("explicit ID", bob.ID, bobID)
+
+	// batches
+	users := []user{{Name: "carol"}, {Name: "dave"}}
+	assert.NoErr("Create batch", db.Create(&users).Error)
+	assert.Ok("batch IDs generated", users[0].ID != UUID{} && users[1].ID != UUID{} &&
+		users[0].ID != users[1].ID)
+
+	var u2 user
+	assert.NoErr("First", db.First(&u2, "id = ?", u.ID).Error)
+	assert.Eq("First", u2, u)
+
+	var all []user
+	assert.NoErr("Find", db.Order("id").Find(&all).Error)
+	assert.Eq("Find count", len(all), 4)
+	for i := 1; i < len(all); i++ {
+		assert.Ok("sorted by UUID", bytes.Compare(all[i-1].ID[:], all[i].ID[:]) < 0)
+	}
+
+	var colType string
+	db.Raw("SELECT type FROM pragma_table_info('users') WHERE name = 'id'").Scan(&colType)
+	assert.Eq("column type", strings.ToLower(colType), "blob")
+}
+
+func TestScan(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+	var u UUID
+	assert.NoErr("Scan bytes", u.Scan(id[:]))
+	assert.Eq("Scan bytes", uuid.UUID(u), id)
+	assert.NoErr("Scan postgres text", u.Scan(id.HexHyphenated()))
+	assert.Eq("Scan postgres text", uuid.UUID(u), id)
+	assert.NoErr("Scan NULL", u.Scan(nil))
+	assert.Eq("Scan NULL", u, UUID{})
+	assert.Err("Scan invalid", "cannot scan int64", u.Scan(int64(1)))
+}
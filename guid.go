@@ -0,0 +1,49 @@
+package uuid
+
+import "fmt"
+
+// GUID returns the UUID formatted as a braced Microsoft GUID string,
+// e.g. "{00310439-02c9-39ce-146c-0bdba1407778}", which is the "B" format of .NET's
+// Guid.ToString. The digits are the same as those of Hex(); parsing the string with
+// .NET's Guid.Parse yields a Guid whose ToByteArray() equals ToGUIDBytes().
+func (id UUID) GUID() string {
+	var buf [38]byte
+	buf[0] = '{'
+	encodeHyphenated(buf[1:37], id)
+	buf[37] = '}'
+	return string(buf[:])
+}
+
+// FromGUID decodes a Microsoft GUID string, with or without braces, as returned by GUID()
+// (see FromHex for the accepted formats without braces.)
+func FromGUID(s string) (UUID, error) {
+	if len(s) > 0 && s[0] == '{' {
+		if len(s) != 38 || s[37] != '}' {
+			return UUID{}, fmt.Errorf("uuid: invalid GUID %q", s)
+		}
+		s = s[1:37]
+	}
+	return FromHex(s)
+}
+
+// ToGUIDBytes returns the UUID in the mixed-endian byte order of Microsoft GUIDs, as used
+// by .NET's Guid.ToByteArray and COM's GUID struct on little-endian machines: the first
+// three groups of a GUID (4, 2 and 2 bytes) are stored little-endian, the rest as-is.
+// The GUID string of the result is the same as that of the UUID (see GUID().)
+func (id UUID) ToGUIDBytes() [16]byte {
+	return swapGUIDBytes(id)
+}
+
+// FromGUIDBytes returns the UUID for a GUID in the mixed-endian byte order used by
+// Microsoft, e.g. from .NET's Guid.ToByteArray (see ToGUIDBytes.)
+func FromGUIDBytes(b [16]byte) UUID {
+	return swapGUIDBytes(b)
+}
+
+// swapGUIDBytes converts between the byte order of UUIDs and GUIDs (in either direction)
+func swapGUIDBytes(b [16]byte) [16]byte {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+	return b
+}
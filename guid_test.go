@@ -0,0 +1,36 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestGUID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id, _ := FromHex("0031043902c939ce146c0bdba1407778")
+	assert.Eq("GUID", id.GUID(), "{00310439-02c9-39ce-146c-0bdba1407778}")
+
+	for _, s := range []string{
+		"{00310439-02c9-39ce-146c-0bdba1407778}",
+		"{00310439-02C9-39CE-146C-0BDBA1407778}",
+		"00310439-02c9-39ce-146c-0bdba1407778",
+	} {
+		id2, err := FromGUID(s)
+		assert.NoErr("FromGUID(%q)", err, s)
+		assert.Eq("FromGUID(%q)", id2, id, s)
+	}
+	_, err := FromGUID("{00310439-02c9-39ce-146c-0bdba1407778")
+	assert.Err("missing brace", "invalid GUID", err)
+	_, err = FromGUID("{}")
+	assert.Err("empty", "invalid GUID", err)
+
+	// new Guid("00310439-02c9-39ce-146c-0bdba1407778").ToByteArray() in .NET
+	b := id.ToGUIDBytes()
+	assert.Eq("ToGUIDBytes", b[:], []byte{
+		0x39, 0x04, 0x31, 0x00, 0xc9, 0x02, 0xce, 0x39,
+		0x14, 0x6c, 0x0b, 0xdb, 0xa1, 0x40, 0x77, 0x78,
+	})
+	assert.Eq("FromGUIDBytes(ToGUIDBytes())", FromGUIDBytes(b), id)
+}
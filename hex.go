@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Hex returns the UUID as a 32 characters long lowercase hexadecimal string,
+// e.g. "0031043902c939ce146c0bdba1407778"
+func (id UUID) Hex() string {
+	var buf [32]byte
+	hex.Encode(buf[:], id[:])
+	return string(buf[:])
+}
+
+// HexHyphenated returns the UUID as 36 characters of lowercase hexadecimal digits in
+// groups of 8-4-4-4-12 separated by hyphens, the form of RFC 9562 which databases like
+// PostgreSQL use for their uuid type, e.g. "00310439-02c9-39ce-146c-0bdba1407778".
+// FromHex decodes the result.
+func (id UUID) HexHyphenated() string {
+	var buf [36]byte
+	encodeHyphenated(buf[:], id)
+	return string(buf[:])
+}
+
+// FromHex decodes a hexadecimal representation of a UUID.
+// s must be either 32 hex digits, as returned by Hex(), or 36 characters with hyphens
+// separating groups of 8-4-4-4-12 digits, like "00310439-02c9-39ce-146c-0bdba1407778".
+// Both upper- and lowercase digits are accepted.
+func FromHex(s string) (UUID, error) {
+	var id UUID
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return id, fmt.Errorf("uuid: invalid hex format %q", s)
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return id, fmt.Errorf("uuid: invalid hex string length %d", len(s))
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return UUID{}, fmt.Errorf("uuid: invalid hex string %q", s)
+	}
+	return id, nil
+}
+
+// URN returns the UUID as a URN as defined by RFC 9562, with the 16 bytes of the UUID as
+// lowercase hexadecimal digits in groups of 8-4-4-4-12,
+// e.g. "urn:uuid:00310439-02c9-39ce-146c-0bdba1407778"
+func (id UUID) URN() string {
+	var buf [9 + 36]byte
+	copy(buf[:], "urn:uuid:")
+	encodeHyphenated(buf[9:], id)
+	return string(buf[:])
+}
+
+// FromURN decodes a URN representation of a UUID, as returned by URN().
+// The "urn:uuid:" prefix is matched case-insensitively.
+func FromURN(s string) (UUID, error) {
+	const prefix = "urn:uuid:"
+	if len(s) != len(prefix)+36 || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return UUID{}, fmt.Errorf("uuid: invalid URN %q", s)
+	}
+	return FromHex(s[len(prefix):])
+}
+
+// encodeHyphenated writes id to dst (which must be at least 36 bytes) as lowercase
+// hexadecimal digits in groups of 8-4-4-4-12 separated by hyphens
+func encodeHyphenated(dst []byte, id UUID) {
+	hex.Encode(dst[0:8], id[0:4])
+	dst[8] = '-'
+	hex.Encode(dst[9:13], id[4:6])
+	dst[13] = '-'
+	hex.Encode(dst[14:18], id[6:8])
+	dst[18] = '-'
+	hex.Encode(dst[19:23], id[8:10])
+	dst[23] = '-'
+	hex.Encode(dst[24:36], id[10:16])
+}
@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestHex(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id := UUID{0x00, 0x31, 0x04, 0x39, 0x02, 0xc9, 0x39, 0xce, 0x14, 0x6c, 0x0b, 0xdb, 0xa1, 0x40, 0x77, 0x78}
+	assert.Eq("Hex", id.Hex(), "0031043902c939ce146c0bdba1407778")
+	assert.Eq("Hex Min", Min.Hex(), "00000000000000000000000000000000")
+	assert.Eq("Hex Max", Max.Hex(), "ffffffffffffffffffffffffffffffff")
+	assert.Eq("HexHyphenated", id.HexHyphenated(), "00310439-02c9-39ce-146c-0bdba1407778")
+	assert.Eq("HexHyphenated in URN", id.URN()[len("urn:uuid:"):], id.HexHyphenated())
+
+	for _, s := range []string{
+		"0031043902c939ce146c0bdba1407778",
+		"0031043902C939CE146C0BDBA1407778",
+		"00310439-02c9-39ce-146c-0bdba1407778",
+	} {
+		id2, err := FromHex(s)
+		assert.NoErr("FromHex(%q)", err, s)
+		assert.Eq("FromHex(%q)", id2, id, s)
+	}
+
+	id3 := MustGen()
+	id4, err := FromHex(id3.Hex())
+	assert.NoErr("FromHex(Hex())", err)
+	assert.Eq("FromHex(Hex())", id4, id3)
+
+	_, err = FromHex("0031043902c939ce146c0bdba140777")
+	assert.Err("short", "invalid hex string length 31", err)
+	_, err = FromHex("0031043902c939ce146c0bdba140777x")
+	assert.Err("non-hex", "invalid hex string", err)
+	_, err = FromHex("0031043-902c9-39ce-146c-0bdba1407778")
+	assert.Err("misplaced hyphen", "invalid hex format", err)
+}
+
+func TestURN(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id, _ := FromHex("0031043902c939ce146c0bdba1407778")
+	assert.Eq("URN", id.URN(), "urn:uuid:00310439-02c9-39ce-146c-0bdba1407778")
+
+	for _, s := range []string{
+		"urn:uuid:00310439-02c9-39ce-146c-0bdba1407778",
+		"URN:UUID:00310439-02C9-39CE-146C-0BDBA1407778",
+	} {
+		id2, err := FromURN(s)
+		assert.NoErr("FromURN(%q)", err, s)
+		assert.Eq("FromURN(%q)", id2, id, s)
+	}
+
+	_, err := FromURN("00310439-02c9-39ce-146c-0bdba1407778")
+	assert.Err("no prefix", "invalid URN", err)
+	_, err = FromURN("urn:uuid:0031043902c939ce146c0bdba1407778")
+	assert.Err("no hyphens", "invalid URN", err)
+	_, err = FromURN("urn:uuid:0031043x-02c9-39ce-146c-0bdba1407778")
+	assert.Err("non-hex", "invalid hex string", err)
+}
@@ -0,0 +1,20 @@
+package uuid
+
+import "encoding/binary"
+
+// ToJavaBits returns the UUID as the two signed 64-bit halves used by java.util.UUID,
+// as returned by its getMostSignificantBits and getLeastSignificantBits methods.
+// The bytes are interpreted in big-endian order, so a java.util.UUID constructed with
+// new UUID(msb, lsb) has the same string representation as Hex() (with hyphens.)
+func (id UUID) ToJavaBits() (msb, lsb int64) {
+	return int64(binary.BigEndian.Uint64(id[:8])), int64(binary.BigEndian.Uint64(id[8:]))
+}
+
+// FromJavaBits returns the UUID for the most and least significant bits of a
+// java.util.UUID (see ToJavaBits.)
+func FromJavaBits(msb, lsb int64) UUID {
+	var id UUID
+	binary.BigEndian.PutUint64(id[:8], uint64(msb))
+	binary.BigEndian.PutUint64(id[8:], uint64(lsb))
+	return id
+}
@@ -0,0 +1,23 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestJavaBits(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// UUID.fromString("00310439-02c9-39ce-946c-0bdba1407778") in Java
+	id, _ := FromHex("0031043902c939ce946c0bdba1407778")
+	msb, lsb := id.ToJavaBits()
+	assert.Eq("msb", msb, int64(0x0031043902c939ce))
+	assert.Eq("lsb", lsb, int64(-7751807820680366216)) // 0x946c0bdba1407778
+	assert.Eq("FromJavaBits(ToJavaBits())", FromJavaBits(msb, lsb), id)
+
+	msb, lsb = Max.ToJavaBits()
+	assert.Ok("Max", msb == -1 && lsb == -1)
+	assert.Eq("FromJavaBits Max", FromJavaBits(-1, -1), Max)
+	assert.Eq("FromJavaBits Min", FromJavaBits(0, 0), Min)
+}
@@ -0,0 +1,60 @@
+package uuid
+
+import (
+	"errors"
+	"math"
+)
+
+// ksuidEpoch is the Unix time which KSUID timestamps are relative to,
+// 2014-05-13 16:53:20 UTC
+const ksuidEpoch = 1400000000
+
+// ErrKSUIDRange is returned when converting an ID whose timestamp can't be represented
+// in the other format. KSUIDs can't represent times after 2150-06-19 23:21:35 UTC and UUIDs
+// can't represent times before 2020-09-13 12:26:40 UTC.
+var ErrKSUIDRange = errors.New("uuid: timestamp out of range for conversion to or from KSUID")
+
+// ToKSUID returns a KSUID (github.com/segmentio/ksuid) with the same timestamp as id.
+//
+// KSUIDs are 20 bytes long with a 4-byte timestamp in seconds followed by a 16-byte payload.
+// The millisecond part of the timestamp of id is scaled to 16 bits and stored at the
+// start of the payload, followed by the 10 random bytes of id and 4 zero bytes.
+// This maintains the sort order of UUIDs and FromKSUID(ToKSUID(id)) == id.
+func (id UUID) ToKSUID() ([20]byte, error) {
+	var k [20]byte
+	sec, ms := id.Timestamp()
+	ts := int64(sec) + (idEpochBase - ksuidEpoch)
+	if ts > math.MaxUint32 {
+		return k, ErrKSUIDRange
+	}
+	if ms > 999 {
+		ms = 999
+	}
+	// ceil(ms * 65536 / 1000), which FromKSUID rounds back down to ms
+	p := uint16((uint32(ms)*65536 + 999) / 1000)
+	k[0] = byte(ts >> 24)
+	k[1] = byte(ts >> 16)
+	k[2] = byte(ts >> 8)
+	k[3] = byte(ts)
+	k[4] = byte(p >> 8)
+	k[5] = byte(p)
+	copy(k[6:16], id[6:])
+	return k, nil
+}
+
+// FromKSUID returns a UUID with the same timestamp as the KSUID k (see ToKSUID.)
+//
+// Since KSUID timestamps only have second precision, the first 16 bits of the payload are
+// scaled to the millisecond part of the timestamp and the following 10 bytes are used as
+// the random bytes of the UUID. The last 4 bytes of the payload are discarded.
+// This maintains the sort order of KSUIDs.
+func FromKSUID(k [20]byte) (UUID, error) {
+	ts := int64(k[0])<<24 | int64(k[1])<<16 | int64(k[2])<<8 | int64(k[3])
+	sec := ts + ksuidEpoch
+	if sec < idEpochBase {
+		return UUID{}, ErrKSUIDRange
+	}
+	p := int(k[4])<<8 | int(k[5])
+	ms := p * 1000 / 65536
+	return New(sec, ms*1000000, k[6:16]), nil
+}
@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestKSUID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// UUID -> KSUID -> UUID is lossless
+	for _, ms := range []int{0, 1, 499, 998, 999} {
+		id := New(1603212345, ms*int(time.Millisecond), []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		k, err := id.ToKSUID()
+		assert.NoErr("ToKSUID", err)
+		ts := int64(k[0])<<24 | int64(k[1])<<16 | int64(k[2])<<8 | int64(k[3])
+		assert.Eq("KSUID timestamp", ts+ksuidEpoch, int64(1603212345))
+		id2, err := FromKSUID(k)
+		assert.NoErr("FromKSUID", err)
+		assert.Eq("FromKSUID(ToKSUID()) ms=%d", id2, id, ms)
+	}
+
+	// KSUID with timestamp 2021-01-07 06:13:20 UTC and a random payload
+	kb, _ := hex.DecodeString("0c845880b5a1cd34b5f99d1154fb6853345c9735")
+	var k [20]byte
+	copy(k[:], kb)
+	id, err := FromKSUID(k)
+	assert.NoErr("FromKSUID", err)
+	assert.Eq("FromKSUID time", id.Time().Truncate(time.Second).UTC(),
+		time.Date(2021, 1, 7, 6, 13, 20, 0, time.UTC))
+	k2, err := id.ToKSUID()
+	assert.NoErr("ToKSUID", err)
+	assert.Eq("ToKSUID(FromKSUID()) keeps timestamp", k2[:4], k[:4])
+	assert.Eq("ToKSUID(FromKSUID()) keeps random bytes", k2[6:16], k[6:16])
+
+	// order is preserved
+	id1 := New(1603212345, 100*int(time.Millisecond), []byte{0xff})
+	id2 := New(1603212345, 101*int(time.Millisecond), []byte{0})
+	k1, _ := id1.ToKSUID()
+	k2, _ = id2.ToKSUID()
+	assert.Ok("order", bytes.Compare(k1[:], k2[:]) < 0)
+
+	// out of range
+	k = [20]byte{}
+	_, err = FromKSUID(k) // 2014
+	assert.Eq("FromKSUID before UUID epoch", err, ErrKSUIDRange)
+	_, err = Max.ToKSUID()
+	assert.Eq("ToKSUID after KSUID range", err, ErrKSUIDRange)
+}
@@ -0,0 +1,82 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout determines how a Generator stores the timestamp of UUIDs in bytes 0-5
+type Layout int
+
+const (
+	// LayoutSeconds stores the seconds since the epoch as a 32-bit big-endian integer in
+	// bytes 0-3 and the millisecond part as a 16-bit integer in bytes 4-5. This is the
+	// layout of UUIDs generated by Gen, which covers the years 2020 to 2156.
+	LayoutSeconds Layout = iota
+
+	// LayoutUnixMilli stores the milliseconds since the Unix epoch as a single 48-bit
+	// big-endian integer in bytes 0-5, like UUIDv7 (RFC 9562) and ULID. This simplifies
+	// interoperability with those formats and covers the years 1970 to 10889.
+	LayoutUnixMilli
+)
+
+// maxUnixMilli is the largest timestamp representable in LayoutUnixMilli
+const maxUnixMilli = 1<<48 - 1
+
+// ToUnixMilliLayout converts id from LayoutSeconds (with the default epoch) to
+// LayoutUnixMilli. Bytes 6-15 are kept as-is. The conversion maintains the sort order of
+// UUIDs, and converting the result back with FromUnixMilliLayout yields id.
+//
+// Note that the conversion does not check the millisecond part of id, so UUIDs with a
+// millisecond part above 999, which Gen never generates, don't round-trip.
+func (id UUID) ToUnixMilliLayout() UUID {
+	putUnixMilli(&id, id.UnixMilli())
+	return id
+}
+
+// FromUnixMilliLayout converts id from LayoutUnixMilli to LayoutSeconds (with the default
+// epoch), the layout expected by the methods of UUID like Time. Bytes 6-15 are kept as-is.
+// An error is returned if the timestamp of id is outside the range of LayoutSeconds
+// (2020-09-13 12:26:40 to 2156-10-20 18:54:55 UTC.)
+func FromUnixMilliLayout(id UUID) (UUID, error) {
+	t := msTime(unixMilliOf(id))
+	if err := checkTime(t); err != nil {
+		return UUID{}, err
+	}
+	id.SetTime(t)
+	return id, nil
+}
+
+// putUnixMilli stores ms in bytes 0-5 of id in LayoutUnixMilli
+func putUnixMilli(id *UUID, ms int64) {
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+}
+
+// unixMilliOf returns the timestamp of id in LayoutUnixMilli
+func unixMilliOf(id UUID) int64 {
+	return int64(id[0])<<40 | int64(id[1])<<32 | int64(id[2])<<24 | int64(id[3])<<16 |
+		int64(id[4])<<8 | int64(id[5])
+}
+
+// setLayoutTime stores the Unix time ms in bytes 0-5 of id according to g.Layout.
+// ms must be within g.msRange().
+func (g *Generator) setLayoutTime(id *UUID, ms int64) error {
+	switch g.Layout {
+	case LayoutSeconds:
+		tid := newID(g.epoch(), floorDiv(ms, 1000), int(floorMod(ms, 1000))*int(time.Millisecond), nil)
+		copy(id[:6], tid[:6])
+	case LayoutUnixMilli:
+		if !g.Epoch.IsZero() {
+			return fmt.Errorf("uuid: Epoch can't be used with LayoutUnixMilli")
+		}
+		putUnixMilli(id, ms)
+	default:
+		return fmt.Errorf("uuid: invalid Layout %d", g.Layout)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestGeneratorLayoutUnixMilli(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	g := &Generator{Layout: LayoutUnixMilli, Now: func() time.Time { return now }}
+	id, err := g.Gen()
+	assert.NoErr("Gen", err)
+	// the first 6 bytes are the Unix time in milliseconds, like UUIDv7
+	assert.Eq("timestamp", unixMilliOf(id), now.UnixMilli())
+	assert.Eq("timestamp bytes", id.Hex()[:12], "018f4cbb3823")
+	assert.Eq("Time", g.Time(id).UTC(), now.Truncate(time.Millisecond))
+	assert.Eq("New", g.New(now.Unix(), now.Nanosecond(), id[6:]), id)
+
+	// times before 2020 and after 2156 are representable
+	for _, tm := range []time.Time{
+		time.Date(1999, 1, 2, 3, 4, 5, 6e6, time.UTC),
+		time.Date(2200, 1, 2, 3, 4, 5, 6e6, time.UTC),
+	} {
+		now = tm
+		id, err := g.Gen()
+		assert.NoErr("Gen", err)
+		assert.Eq("Time", g.Time(id).UTC(), tm)
+	}
+
+	// sort order
+	now = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	a, _ := g.Gen()
+	now = now.Add(time.Millisecond)
+	b, _ := g.Gen()
+	assert.Ok("sorted", bytes.Compare(a[:], b[:]) < 0)
+
+	now = time.Unix(0, 0).Add(-time.Millisecond)
+	_, err = g.Gen()
+	assert.Err("before 1970", "out of range", err)
+	_, err = (&Generator{Layout: LayoutUnixMilli, Epoch: time.Unix(1700000000, 0)}).Gen()
+	assert.Err("Epoch", "Epoch can't be used with LayoutUnixMilli", err)
+	_, err = (&Generator{Layout: Layout(7)}).Gen()
+	assert.Err("invalid Layout", "invalid Layout 7", err)
+}
+
+func TestUnixMilliLayoutConversion(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	v2 := id.ToUnixMilliLayout()
+	assert.Eq("timestamp", unixMilliOf(v2), id.UnixMilli())
+	assert.Eq("random kept", v2[6:], id[6:])
+	assert.Eq("Time", (&Generator{Layout: LayoutUnixMilli}).Time(v2).UTC(), id.Time().UTC())
+	id2, err := FromUnixMilliLayout(v2)
+	assert.NoErr("FromUnixMilliLayout", err)
+	assert.Eq("round trip", id2, id)
+
+	// matches UUIDs generated in the other layout
+	now := time.Now()
+	g1 := &Generator{Now: func() time.Time { return now }}
+	g2 := &Generator{Now: func() time.Time { return now }, Layout: LayoutUnixMilli}
+	a, _ := g1.Gen()
+	b, _ := g2.Gen()
+	a2 := a.ToUnixMilliLayout()
+	assert.Eq("same timestamp", a2[:8], b[:8])
+
+	// order is maintained
+	ids := []UUID{Min, MustGen(), MustGen(), Max}
+	slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+	for i := 1; i < len(ids); i++ {
+		x, y := ids[i-1].ToUnixMilliLayout(), ids[i].ToUnixMilliLayout()
+		assert.Ok("sorted", bytes.Compare(x[:], y[:]) <= 0)
+	}
+
+	var old UUID
+	putUnixMilli(&old, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli())
+	_, err = FromUnixMilliLayout(old)
+	assert.Err("out of range", "uuid: time 2019-01-01T00:00:00Z out of range", err)
+}
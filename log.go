@@ -0,0 +1,35 @@
+package uuid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+)
+
+// LogValue implements the slog.LogValuer interface, making UUIDs appear as strings (as
+// returned by String()) in structured logs rather than as arrays of 16 numbers.
+// The string is only created if the log record is actually emitted.
+func (id UUID) LogValue() slog.Value {
+	return slog.StringValue(id.String())
+}
+
+// Redacted returns a masked form of the UUID for logs and error messages in environments
+// where full identifiers are considered sensitive, e.g. "MOpu~6a2196e1". It is the first 4
+// characters of String() followed by '~' and 8 hex digits of the SHA-256 hash of the UUID.
+//
+// The same UUID always yields the same redacted form, so it can still be used to correlate
+// log lines or to look for a known ID, but the UUID can't be recovered from it.
+func (id UUID) Redacted() string {
+	const prefixLen = 4
+	var sbuf [StringMaxLen]byte
+	s := sbuf[id.EncodeString(sbuf[:]):]
+	if len(s) > prefixLen {
+		s = s[:prefixLen]
+	}
+	sum := sha256.Sum256(id[:])
+	buf := make([]byte, 0, prefixLen+1+8)
+	buf = append(buf, s...)
+	buf = append(buf, '~')
+	buf = hex.AppendEncode(buf, sum[:4])
+	return string(buf)
+}
@@ -0,0 +1,46 @@
+package uuid
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestLogValue(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	var _ slog.LogValuer = id
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("hello", "id", id)
+	assert.Eq("text", buf.String(), "level=INFO msg=hello id="+id.String()+"\n")
+
+	buf.Reset()
+	logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("hello", "id", id)
+	assert.Ok("json %q", bytes.Contains(buf.Bytes(), []byte(`"id":"`+id.String()+`"`)), buf.String())
+}
+
+func TestRedacted(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustFromString("MOpuNo4XU2HUSbBwf29A")
+	r := id.Redacted()
+	assert.Eq("Redacted", r, "MOpu~6a2196e1")
+	assert.Eq("Redacted length", len(r), 13)
+	assert.Eq("stable", id.Redacted(), r)
+
+	id2 := id
+	id2[15]++
+	assert.Ok("differs", id2.Redacted() != r && id2.Redacted()[:5] == "MOpu~")
+	assert.Eq("short string", UUID{}.Redacted()[:2], "0~")
+}
@@ -0,0 +1,44 @@
+package uuid
+
+// SQL Server stores uniqueidentifier values in the mixed-endian byte order of Microsoft
+// GUIDs (see ToGUIDBytes) and compares them by those bytes in the order given by
+// mssqlOrder, most significant first. This is the comparison of System.Data.SqlTypes.SqlGuid.
+var mssqlOrder = [16]int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+// ToMSSQLBytes returns the UUID as the bytes of a SQL Server uniqueidentifier whose string
+// form is the same as that of the UUID (see GUID().) These are the bytes sent and received
+// by drivers when a uniqueidentifier is read or written as []byte.
+//
+// Note that SQL Server does not sort uniqueidentifiers in the order of UUIDs, so time
+// ordering is lost; see ToMSSQLOrdered for an alternative that keeps it.
+func (id UUID) ToMSSQLBytes() [16]byte {
+	return id.ToGUIDBytes()
+}
+
+// FromMSSQLBytes returns the UUID for the bytes of a SQL Server uniqueidentifier, the inverse
+// of ToMSSQLBytes
+func FromMSSQLBytes(b [16]byte) UUID {
+	return FromGUIDBytes(b)
+}
+
+// ToMSSQLOrdered returns the bytes of a SQL Server uniqueidentifier which sorts in the same
+// order as the UUIDs: ORDER BY and index range scans over such a column keep the time
+// ordering of UUIDs. The byte mapping is a permutation and FromMSSQLOrdered reverses it,
+// but the string form of the uniqueidentifier differs from that of the UUID.
+func (id UUID) ToMSSQLOrdered() [16]byte {
+	var b [16]byte
+	for i, j := range mssqlOrder {
+		b[j] = id[i]
+	}
+	return b
+}
+
+// FromMSSQLOrdered returns the UUID for the bytes of a uniqueidentifier created by
+// ToMSSQLOrdered
+func FromMSSQLOrdered(b [16]byte) UUID {
+	var id UUID
+	for i, j := range mssqlOrder {
+		id[i] = b[j]
+	}
+	return id
+}
@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+// mssqlCompare compares the bytes of two uniqueidentifiers like SQL Server does
+func mssqlCompare(a, b [16]byte) int {
+	for _, i := range mssqlOrder {
+		if a[i] != b[i] {
+			return int(a[i]) - int(b[i])
+		}
+	}
+	return 0
+}
+
+func TestMSSQL(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id, _ := FromHex("0031043902c939ce146c0bdba1407778")
+	b := id.ToMSSQLBytes()
+	assert.Eq("ToMSSQLBytes", b, id.ToGUIDBytes())
+	assert.Eq("FromMSSQLBytes(ToMSSQLBytes())", FromMSSQLBytes(b), id)
+
+	b = id.ToMSSQLOrdered()
+	assert.Eq("ToMSSQLOrdered", b[:], []byte{
+		0xa1, 0x40, 0x77, 0x78, 0x0b, 0xdb, 0x14, 0x6c,
+		0x39, 0xce, 0x00, 0x31, 0x04, 0x39, 0x02, 0xc9,
+	})
+	assert.Eq("FromMSSQLOrdered(ToMSSQLOrdered())", FromMSSQLOrdered(b), id)
+
+	// SQL Server sorts ordered uniqueidentifiers like the UUIDs
+	ids := make([]UUID, 200)
+	for i := range ids {
+		ids[i] = MustGen()
+		ids[i][i%16] ^= 0xff // vary all bytes
+	}
+	slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+	ordered := make([][16]byte, len(ids))
+	for i, id := range ids {
+		ordered[i] = id.ToMSSQLOrdered()
+	}
+	assert.Ok("sorted by SQL Server", slices.IsSortedFunc(ordered, mssqlCompare))
+	plain := make([][16]byte, len(ids))
+	for i, id := range ids {
+		plain[i] = id.ToMSSQLBytes()
+	}
+	assert.Ok("plain bytes not sorted by SQL Server", !slices.IsSortedFunc(plain, mssqlCompare))
+}
@@ -0,0 +1,30 @@
+package uuid
+
+// ToMySQLSwapped returns the UUID in the byte order produced by MySQL's
+// UUID_TO_BIN(uuid, 1), where uuid is the hyphenated hex representation of id: the third
+// group of the string (bytes 6-7) is moved first, followed by the second (bytes 4-5) and
+// the first (bytes 0-3) group. Use this to read and write BINARY(16) columns populated
+// with UUID_TO_BIN(..., 1) and BIN_TO_UUID(..., 1).
+//
+// The swap exists to make RFC version 1 UUIDs sort by time. UUIDs of this package already
+// do, and swapping their bytes breaks that order, so only use this for interoperability
+// with existing columns.
+func (id UUID) ToMySQLSwapped() [16]byte {
+	var b [16]byte
+	copy(b[0:2], id[6:8])
+	copy(b[2:4], id[4:6])
+	copy(b[4:8], id[0:4])
+	copy(b[8:], id[8:])
+	return b
+}
+
+// FromMySQLSwapped returns the UUID for bytes in the order produced by MySQL's
+// UUID_TO_BIN(uuid, 1), the inverse of ToMySQLSwapped
+func FromMySQLSwapped(b [16]byte) UUID {
+	var id UUID
+	copy(id[0:4], b[4:8])
+	copy(id[4:6], b[2:4])
+	copy(id[6:8], b[0:2])
+	copy(id[8:], b[8:])
+	return id
+}
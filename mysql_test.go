@@ -0,0 +1,22 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestMySQLSwapped(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// example from the MySQL manual:
+	// HEX(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)) = '1026BABA6CCD780C95645B8C656024DB'
+	id, _ := FromHex("6ccd780c-baba-1026-9564-5b8c656024db")
+	b := id.ToMySQLSwapped()
+	expect, _ := FromHex("1026BABA6CCD780C95645B8C656024DB")
+	assert.Eq("ToMySQLSwapped", UUID(b), expect)
+	assert.Eq("FromMySQLSwapped(ToMySQLSwapped())", FromMySQLSwapped(b), id)
+
+	id2 := MustGen()
+	assert.Eq("round trip", FromMySQLSwapped(id2.ToMySQLSwapped()), id2)
+}
@@ -0,0 +1,43 @@
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+// Obfuscator maps UUIDs to unguessable public UUIDs and back, using a secret key.
+// This allows exposing IDs in external APIs which reveal neither the time of creation nor
+// the order of the IDs while storing the sortable originals in databases.
+//
+// The mapping is a keyed permutation of all 128-bit values: each UUID is encrypted as a
+// single AES block. As such, every UUID "deobfuscates" to some UUID; an ID received from a
+// client must still be looked up to know if it's valid. Obfuscators are safe for concurrent
+// use.
+type Obfuscator struct {
+	block cipher.Block
+}
+
+// NewObfuscator returns an Obfuscator for key, which must be 16, 24 or 32 bytes long
+// (selecting AES-128, AES-192 or AES-256) and should be random and kept secret.
+func NewObfuscator(key []byte) (*Obfuscator, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("uuid: invalid Obfuscator key: %w", err)
+	}
+	return &Obfuscator{block: block}, nil
+}
+
+// Obfuscate returns the public UUID for id
+func (o *Obfuscator) Obfuscate(id UUID) UUID {
+	var r UUID
+	o.block.Encrypt(r[:], id[:])
+	return r
+}
+
+// Deobfuscate returns the original UUID for a public UUID returned by Obfuscate
+func (o *Obfuscator) Deobfuscate(public UUID) UUID {
+	var r UUID
+	o.block.Decrypt(r[:], public[:])
+	return r
+}
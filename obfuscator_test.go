@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestObfuscator(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	o, err := NewObfuscator([]byte("0123456789abcdef"))
+	assert.NoErr("NewObfuscator", err)
+
+	id := MustGen()
+	pub := o.Obfuscate(id)
+	assert.Ok("obfuscated", pub != id)
+	assert.Eq("Deobfuscate(Obfuscate())", o.Deobfuscate(pub), id)
+	assert.Eq("deterministic", o.Obfuscate(id), pub)
+
+	// consecutive IDs map to unrelated public IDs
+	id2 := id
+	id2[15]++
+	pub2 := o.Obfuscate(id2)
+	same := 0
+	for i := range pub {
+		if pub[i] == pub2[i] {
+			same++
+		}
+	}
+	assert.Ok("%d equal bytes", same < 4, same)
+
+	// different keys give different mappings
+	o2, _ := NewObfuscator([]byte("0123456789abcdeF"))
+	assert.Ok("key dependent", o2.Obfuscate(id) != pub)
+
+	_, err = NewObfuscator([]byte("short"))
+	assert.Err("invalid key", "invalid Obfuscator key", err)
+}
@@ -0,0 +1,52 @@
+package uuid
+
+import (
+	"fmt"
+	"math"
+)
+
+// FromObjectID returns a UUID for the MongoDB ObjectID oid.
+//
+// ObjectIDs are 12 bytes: a 4-byte Unix timestamp in seconds followed by 5 random bytes and
+// a 3-byte counter. The UUID gets the same timestamp (with a zero millisecond part) and the
+// 8 bytes following the timestamp are stored in bytes 6-13, with bytes 14-15 set to zero.
+// The mapping is deterministic and maintains the order of ObjectIDs.
+// An error is returned if the timestamp of oid is before the UUID epoch (2020-09-13.)
+func FromObjectID(oid [12]byte) (UUID, error) {
+	return fromSecondID(oid, "ObjectID")
+}
+
+// ToObjectID returns a MongoDB ObjectID with the timestamp of id (see FromObjectID.)
+// The millisecond part of the timestamp and bytes 14-15 of id are lost, while
+// ToObjectID(FromObjectID(oid)) == oid.
+// An error is returned if the timestamp of id is after 2106-02-07 06:28:15 UTC, the last
+// time representable by an ObjectID.
+func (id UUID) ToObjectID() ([12]byte, error) {
+	return id.toSecondID("ObjectID")
+}
+
+// fromSecondID converts a 12-byte ID with a 4-byte Unix timestamp in seconds, like
+// ObjectID and xid, to a UUID. kind names the ID type in error messages.
+func fromSecondID(b [12]byte, kind string) (UUID, error) {
+	sec := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	if sec < idEpochBase {
+		return UUID{}, fmt.Errorf("uuid: %s timestamp %d out of range", kind, sec)
+	}
+	return New(sec, 0, b[4:12]), nil
+}
+
+// toSecondID is the inverse of fromSecondID
+func (id UUID) toSecondID(kind string) ([12]byte, error) {
+	var oid [12]byte
+	sec, _ := id.Timestamp()
+	ts := int64(sec) + idEpochBase
+	if ts > math.MaxUint32 {
+		return oid, fmt.Errorf("uuid: timestamp out of range for %s", kind)
+	}
+	oid[0] = byte(ts >> 24)
+	oid[1] = byte(ts >> 16)
+	oid[2] = byte(ts >> 8)
+	oid[3] = byte(ts)
+	copy(oid[4:], id[6:14])
+	return oid, nil
+}
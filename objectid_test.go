@@ -0,0 +1,44 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestObjectID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 6526a3c2 = 2023-10-11 13:31:46 UTC
+	var oid [12]byte
+	hex.Decode(oid[:], []byte("6526a3c2f1e2d3c4b5a69788"))
+	id, err := FromObjectID(oid)
+	assert.NoErr("FromObjectID", err)
+	assert.Eq("FromObjectID time", id.Time().UTC(), time.Date(2023, 10, 11, 13, 31, 46, 0, time.UTC))
+	assert.Eq("FromObjectID bytes", id[6:], []byte{0xf1, 0xe2, 0xd3, 0xc4, 0xb5, 0xa6, 0x97, 0x88, 0, 0})
+
+	oid2, err := id.ToObjectID()
+	assert.NoErr("ToObjectID", err)
+	assert.Eq("ToObjectID(FromObjectID())", oid2, oid)
+
+	// order is maintained
+	oid3 := oid
+	oid3[11]++
+	id3, _ := FromObjectID(oid3)
+	assert.Ok("order", bytes.Compare(id[:], id3[:]) < 0)
+
+	// UUID -> ObjectID keeps the second timestamp
+	id4 := MustGen()
+	oid4, err := id4.ToObjectID()
+	assert.NoErr("ToObjectID", err)
+	id5, _ := FromObjectID(oid4)
+	assert.Eq("timestamp", id5.Time(), id4.Time().Truncate(time.Second))
+
+	_, err = FromObjectID([12]byte{})
+	assert.Err("FromObjectID before epoch", "out of range", err)
+	_, err = Max.ToObjectID()
+	assert.Err("ToObjectID after 2106", "out of range", err)
+}
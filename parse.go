@@ -0,0 +1,129 @@
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseAny decodes a UUID in any of the string forms this package produces, telling them
+// apart by their length:
+//
+//	up to 22 characters  base62, as returned by String() (see Parse)
+//	32 characters        hexadecimal, as returned by Hex()
+//	36 characters        hexadecimal in groups of 8-4-4-4-12, as in RFC 9562
+//	38 characters        braced GUID, as returned by GUID()
+//	45 characters        URN, as returned by URN()
+//
+// Use this where input comes from systems using different representations, e.g. when
+// ingesting data. Where a single representation is expected, use the function decoding
+// just that form instead, since ParseAny accepts input which would be rejected there.
+func ParseAny(s string) (UUID, error) {
+	switch {
+	case len(s) <= StringMaxLen:
+		return Parse(s)
+	case len(s) == 32 || len(s) == 36:
+		return FromHex(s)
+	case len(s) == 38 && s[0] == '{':
+		return FromGUID(s)
+	case len(s) == 45 && strings.HasPrefix(strings.ToLower(s[:4]), "urn:"):
+		return FromURN(s)
+	}
+	return UUID{}, fmt.Errorf("uuid: unrecognized format of %d characters long string", len(s))
+}
+
+// Format identifies a string representation of UUIDs, for use with ParseFormat
+type Format int
+
+const (
+	FormatBase62       Format = iota // base62, as returned by String()
+	FormatBase62Padded               // base62 of exactly 22 characters, as returned by StringPadded()
+	FormatHex                        // 32 hexadecimal digits, as returned by Hex()
+	FormatRFC                        // 36 characters in groups of 8-4-4-4-12, as in RFC 9562
+	FormatGUID                       // braced GUID, as returned by GUID()
+	FormatURN                        // URN, as returned by URN()
+	FormatBase32                     // Crockford base32, as returned by String32()
+	FormatBase58                     // base58, as returned by String58()
+	FormatBase64URL                  // URL-safe base64, as returned by ToBase64URL()
+	FormatProquint                   // proquint words, as returned by Proquint()
+	FormatCheck                      // base62 with check character, as returned by StringWithCheck()
+	FormatDNSLabel                   // DNS label, as returned by DNSLabel()
+)
+
+var formatNames = [...]string{
+	FormatBase62:       "base62",
+	FormatBase62Padded: "padded base62",
+	FormatHex:          "hex",
+	FormatRFC:          "RFC 9562",
+	FormatGUID:         "GUID",
+	FormatURN:          "URN",
+	FormatBase32:       "base32",
+	FormatBase58:       "base58",
+	FormatBase64URL:    "base64url",
+	FormatProquint:     "proquint",
+	FormatCheck:        "check character",
+	FormatDNSLabel:     "DNS label",
+}
+
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+	return formatNames[f]
+}
+
+// ParseFormat decodes s, which must be in format f, rejecting any other representation.
+// Use this where an API accepts exactly one representation; see ParseAny to accept all.
+//
+// Note that some formats share characters: FormatBase62 accepts the strings of
+// FormatBase62Padded, and strings of FormatBase58 are also valid FormatBase62 strings
+// (of a different UUID), for example.
+func ParseFormat(s string, f Format) (UUID, error) {
+	switch f {
+	case FormatBase62:
+		return Parse(s)
+	case FormatBase62Padded:
+		if err := checkFormatLen(s, f, StringMaxLen); err != nil {
+			return UUID{}, err
+		}
+		return Parse(s)
+	case FormatHex:
+		if err := checkFormatLen(s, f, 32); err != nil {
+			return UUID{}, err
+		}
+		return FromHex(s)
+	case FormatRFC:
+		if err := checkFormatLen(s, f, 36); err != nil {
+			return UUID{}, err
+		}
+		return FromHex(s)
+	case FormatGUID:
+		if err := checkFormatLen(s, f, 38); err != nil {
+			return UUID{}, err
+		}
+		return FromGUID(s)
+	case FormatURN:
+		return FromURN(s)
+	case FormatBase32:
+		return FromString32(s)
+	case FormatBase58:
+		return FromString58(s)
+	case FormatBase64URL:
+		return FromBase64URL(s)
+	case FormatProquint:
+		return FromProquint(s)
+	case FormatCheck:
+		return ParseWithCheck(s)
+	case FormatDNSLabel:
+		return FromDNSLabel(s)
+	}
+	return UUID{}, fmt.Errorf("uuid: unknown format %s", f)
+}
+
+// checkFormatLen returns an error if s is not n bytes long, for formats whose decoder
+// also accepts other forms
+func checkFormatLen(s string, f Format, n int) error {
+	if len(s) != n {
+		return fmt.Errorf("uuid: invalid %s string length %d (expected %d)", f, len(s), n)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestParseAny(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	for _, id := range []UUID{MustFromString("MOpuNo4XU2HUSbBwf29A"), MustGen(), Max, {}} {
+		for _, s := range []string{
+			id.String(),
+			id.StringPadded(),
+			id.Hex(),
+			strings.ToUpper(id.Hex()),
+			id.URN()[9:],
+			id.GUID(),
+			strings.ToLower(id.GUID()),
+			id.URN(),
+			strings.ToUpper(id.URN()),
+		} {
+			id2, err := ParseAny(s)
+			assert.NoErr("ParseAny "+s, err)
+			assert.Eq("ParseAny "+s, id2, id)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"MOpuNo4XU2HUSbBwf29A!",
+		strings.Repeat("0", 23),
+		strings.Repeat("g", 32),
+		"{" + strings.Repeat("0", 36) + "}",
+		"[00310439-02c9-39ce-146c-0bdba1407778]",
+		"uri:uuid:00310439-02c9-39ce-146c-0bdba1407778",
+		"urn:uuid:00310439-02c9-39ce-146c-0bdba14077781",
+	} {
+		_, err := ParseAny(s)
+		assert.Ok("ParseAny "+s, err != nil)
+	}
+	_, err := ParseAny(strings.Repeat("0", 23))
+	assert.Err("unrecognized", "unrecognized format of 23 characters long string", err)
+}
+
+func TestParseFormat(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustFromString("MOpuNo4XU2HUSbBwf29A")
+	encoded := map[Format]string{
+		FormatBase62:       id.String(),
+		FormatBase62Padded: id.StringPadded(),
+		FormatHex:          id.Hex(),
+		FormatRFC:          id.URN()[9:],
+		FormatGUID:         id.GUID(),
+		FormatURN:          id.URN(),
+		FormatBase32:       id.String32(),
+		FormatBase58:       id.String58(),
+		FormatBase64URL:    id.ToBase64URL(),
+		FormatProquint:     id.Proquint(),
+		FormatCheck:        id.StringWithCheck(),
+		FormatDNSLabel:     id.DNSLabel(),
+	}
+	assert.Eq("all formats", len(encoded), len(formatNames))
+	ambiguous := map[[2]Format]bool{
+		{FormatBase62, FormatBase62Padded}:    true,
+		{FormatBase62, FormatBase58}:          true,
+		{FormatBase62Padded, FormatBase58}:    true,
+		{FormatBase64URL, FormatBase62Padded}: true,
+		{FormatBase64URL, FormatBase58}:       true,
+	}
+	for f, s := range encoded {
+		id2, err := ParseFormat(s, f)
+		assert.NoErr("ParseFormat "+f.String(), err)
+		assert.Eq("ParseFormat "+f.String(), id2, id)
+		// every other representation is rejected, except for those which are valid in
+		// both formats as the alphabets of base62, base58 and base64url overlap
+		for f2, s2 := range encoded {
+			if f2 != f && !ambiguous[[2]Format{f, f2}] {
+				_, err := ParseFormat(s2, f)
+				assert.Ok("ParseFormat "+f.String()+" rejects "+f2.String(), err != nil)
+			}
+		}
+	}
+
+	_, err := ParseFormat(id.URN()[9:], FormatHex)
+	assert.Err("precise error", "uuid: invalid hex string length 36 (expected 32)", err)
+	_, err = ParseFormat(id.URN()[9:], FormatGUID)
+	assert.Err("precise error", "uuid: invalid GUID string length 36 (expected 38)", err)
+	_, err = ParseFormat(id.String(), Format(99))
+	assert.Err("unknown format", "uuid: unknown format Format(99)", err)
+	assert.Eq("Format.String", FormatRFC.String(), "RFC 9562")
+}
@@ -0,0 +1,130 @@
+package uuid
+
+import (
+	"math"
+	"time"
+)
+
+// Range is a half-open range [Start, End) of UUIDs
+type Range struct {
+	Start UUID // inclusive
+	End   UUID // exclusive
+}
+
+// Contains reports whether id is within r
+func (r Range) Contains(id UUID) bool {
+	return string(id[:]) >= string(r.Start[:]) && string(id[:]) < string(r.End[:])
+}
+
+// TimeRange returns the range of all UUIDs with a time in [start, end).
+// Times are truncated to millisecond precision. Times before the beginning of the UUID
+// epoch map to Min and times after the end of it map to Max.
+func TimeRange(start, end time.Time) Range {
+	return Range{timeBound(start), timeBound(end)}
+}
+
+// PartitionTimeRange splits the time interval [start, end) into n contiguous ranges of
+// UUIDs spanning equal amounts of time. The ranges are in ascending order, the End of each
+// range is the Start of the following one, and together they cover TimeRange(start, end),
+// which makes them suitable as boundaries of parallel scans over a table ordered by UUID.
+//
+// Boundaries have millisecond precision, so some ranges are empty if the interval is
+// shorter than n milliseconds. PartitionTimeRange panics if n <= 0.
+func PartitionTimeRange(start, end time.Time, n int) []Range {
+	return PartitionTimeRangeFunc(start, end, n, nil)
+}
+
+// PartitionTimeRangeFunc is like PartitionTimeRange but splits [start, end) into ranges of
+// equal estimated volume rather than equal time spans. density returns the relative rate of
+// UUIDs created around time t, e.g. requests per second from a traffic profile; its scale
+// doesn't matter and negative values count as zero.
+//
+// density is sampled at up to 4096 evenly spaced points over the interval. If density is
+// nil or zero everywhere, time is split evenly like PartitionTimeRange.
+func PartitionTimeRangeFunc(
+	start, end time.Time, n int, density func(t time.Time) float64,
+) []Range {
+	if n <= 0 {
+		panic("uuid: invalid argument to PartitionTimeRange")
+	}
+	t0, t1 := start.UnixMilli(), end.UnixMilli()
+	if t1 < t0 {
+		t1 = t0
+	}
+	bounds := make([]int64, n+1)
+	bounds[0], bounds[n] = t0, t1
+	span := t1 - t0
+	if !partitionByDensity(bounds, density) {
+		for k := 1; k < n; k++ {
+			bounds[k] = t0 + int64(float64(span)*float64(k)/float64(n))
+		}
+	}
+	ranges := make([]Range, n)
+	prev := timeBound(time.UnixMilli(bounds[0]))
+	for k := range ranges {
+		next := timeBound(time.UnixMilli(bounds[k+1]))
+		ranges[k] = Range{prev, next}
+		prev = next
+	}
+	return ranges
+}
+
+// partitionByDensity fills bounds[1:len(bounds)-1] with Unix millisecond times dividing
+// [bounds[0], bounds[len(bounds)-1]) into intervals of equal integrated density.
+// Returns false if density is nil or integrates to zero.
+func partitionByDensity(bounds []int64, density func(t time.Time) float64) bool {
+	n := len(bounds) - 1
+	t0, span := bounds[0], bounds[n]-bounds[0]
+	if density == nil || span == 0 {
+		return false
+	}
+	steps := int64(4096)
+	if span < steps {
+		steps = span
+	}
+	step := float64(span) / float64(steps)
+	cum := make([]float64, steps+1)
+	for i := int64(0); i < steps; i++ {
+		mid := float64(t0) + (float64(i)+0.5)*step
+		d := density(time.UnixMilli(int64(math.Round(mid))))
+		if !(d > 0) || math.IsInf(d, 1) {
+			d = 0
+		}
+		cum[i+1] = cum[i] + d
+	}
+	total := cum[steps]
+	if !(total > 0) || math.IsInf(total, 1) {
+		return false
+	}
+	i := int64(0)
+	for k := 1; k < n; k++ {
+		target := total * float64(k) / float64(n)
+		for i < steps-1 && cum[i+1] < target {
+			i++
+		}
+		// interpolate within step i
+		f := 0.0
+		if w := cum[i+1] - cum[i]; w > 0 {
+			f = (target - cum[i]) / w
+		}
+		t := t0 + int64(math.Round((float64(i)+f)*step))
+		if t < bounds[k-1] {
+			t = bounds[k-1]
+		}
+		bounds[k] = t
+	}
+	return true
+}
+
+// timeBound returns the smallest UUID with time t (truncated to milliseconds), clamping
+// times outside of the UUID epoch to Min and Max
+func timeBound(t time.Time) UUID {
+	ms := t.UnixMilli() - idEpochBase*1000
+	if ms < 0 {
+		return Min
+	}
+	if ms/1000 > math.MaxUint32 {
+		return Max
+	}
+	return New(ms/1000+idEpochBase, int(ms%1000)*int(time.Millisecond), nil)
+}
@@ -0,0 +1,79 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestPartitionTimeRange(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	ranges := PartitionTimeRange(start, end, 4)
+	assert.Eq("len", len(ranges), 4)
+	assert.Eq("first Start", ranges[0].Start, TimeRange(start, end).Start)
+	assert.Eq("last End", ranges[3].End, TimeRange(start, end).End)
+	for i, r := range ranges {
+		assert.Eq("Start time", r.Start.Time().UTC(), start.Add(time.Duration(i)*6*time.Hour))
+		assert.Eq("Start random", r.Start.Random(), [10]byte{})
+		if i > 0 {
+			assert.Eq("contiguous", r.Start, ranges[i-1].End)
+		}
+	}
+
+	// every UUID in the interval falls in exactly one range
+	for _, tm := range []time.Time{start, start.Add(6*time.Hour - time.Millisecond), end.Add(-1)} {
+		id := New(tm.Unix(), tm.Nanosecond(), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+		count := 0
+		for _, r := range ranges {
+			if r.Contains(id) {
+				count++
+			}
+		}
+		assert.Eq("contained in one range", count, 1)
+	}
+	id := New(end.Unix(), 0, nil)
+	assert.Ok("end is exclusive", !ranges[3].Contains(id))
+
+	// short intervals yield empty ranges
+	ranges = PartitionTimeRange(start, start.Add(2*time.Millisecond), 4)
+	assert.Eq("short len", len(ranges), 4)
+	assert.Eq("short last End", ranges[3].End.Time().UTC(), start.Add(2*time.Millisecond))
+
+	// clamping
+	r := TimeRange(time.Unix(0, 0), time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Eq("clamp Start", r.Start, Min)
+	assert.Eq("clamp End", r.End, Max)
+
+	assert.Panic("invalid argument", func() { PartitionTimeRange(start, end, 0) })
+}
+
+func TestPartitionTimeRangeFunc(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	// three times as much traffic during the first two hours
+	density := func(t time.Time) float64 {
+		if t.Before(start.Add(2 * time.Hour)) {
+			return 3
+		}
+		return 1
+	}
+	ranges := PartitionTimeRangeFunc(start, end, 4, density)
+	expect := []time.Duration{0, 40 * time.Minute, 80 * time.Minute, 120 * time.Minute}
+	for i, r := range ranges {
+		d := r.Start.Time().Sub(start)
+		diff := d - expect[i]
+		assert.Ok("range %d starts at %v, expected about %v", diff > -5*time.Second && diff < 5*time.Second,
+			i, d, expect[i])
+	}
+	assert.Eq("last End", ranges[3].End.Time().UTC(), end)
+
+	// zero density falls back to equal spans
+	ranges = PartitionTimeRangeFunc(start, end, 4, func(time.Time) float64 { return 0 })
+	assert.Eq("zero density", ranges[1].Start.Time().UTC(), start.Add(time.Hour))
+}
@@ -0,0 +1,108 @@
+// Package pgxuuid integrates github.com/rsms/go-uuid UUIDs with github.com/jackc/pgx/v5,
+// mapping uuid.UUID to the native PostgreSQL uuid type.
+//
+// UUIDs are sent and received in the binary wire format, storing the 16 bytes of the UUID
+// verbatim, so the sort order of a uuid column matches that of the UUIDs. Arrays
+// ([]uuid.UUID to and from uuid[]) are supported as well.
+//
+// Register the type with a connection, e.g. for a pgxpool.Pool:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+package pgxuuid
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rsms/go-uuid"
+)
+
+// UUID wraps uuid.UUID to implement pgtype.UUIDScanner and pgtype.UUIDValuer
+type UUID uuid.UUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	if !v.Valid {
+		return fmt.Errorf("uuid: cannot scan NULL into *uuid.UUID")
+	}
+	*u = v.Bytes
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: [16]byte(u), Valid: true}, nil
+}
+
+// Register registers uuid.UUID with m, for the uuid type as well as uuid[] arrays
+func Register(m *pgtype.Map) {
+	m.TryWrapEncodePlanFuncs = append(
+		[]pgtype.TryWrapEncodePlanFunc{TryWrapUUIDEncodePlan}, m.TryWrapEncodePlanFuncs...)
+	m.TryWrapScanPlanFuncs = append(
+		[]pgtype.TryWrapScanPlanFunc{TryWrapUUIDScanPlan}, m.TryWrapScanPlanFuncs...)
+
+	t := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}}
+	m.RegisterType(t)
+	m.RegisterType(&pgtype.Type{
+		Name:  "_uuid",
+		OID:   pgtype.UUIDArrayOID,
+		Codec: &pgtype.ArrayCodec{ElementType: t},
+	})
+}
+
+// TryWrapUUIDEncodePlan is a pgtype.TryWrapEncodePlanFunc for uuid.UUID
+func TryWrapUUIDEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	if id, ok := value.(uuid.UUID); ok {
+		return &wrapUUIDEncodePlan{}, UUID(id), true
+	}
+	return nil, nil, false
+}
+
+type wrapUUIDEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapUUIDEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapUUIDEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(UUID(value.(uuid.UUID)), buf)
+}
+
+// TryWrapUUIDScanPlan is a pgtype.TryWrapScanPlanFunc for *uuid.UUID
+func TryWrapUUIDScanPlan(target any) (plan pgtype.WrappedScanPlanNextSetter, nextTarget any, ok bool) {
+	if id, ok := target.(*uuid.UUID); ok {
+		return &wrapUUIDScanPlan{}, (*UUID)(id), true
+	}
+	return nil, nil, false
+}
+
+type wrapUUIDScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapUUIDScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapUUIDScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*UUID)(dst.(*uuid.UUID)))
+}
+
+// Codec is pgtype.UUIDCodec with values decoded as uuid.UUID rather than as a
+// pgtype.UUID, e.g. by Rows.Values
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// DecodeValue implements the pgtype.Codec interface
+func (Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var id uuid.UUID
+	if err := m.PlanScan(oid, format, &id).Scan(src, &id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
@@ -0,0 +1,59 @@
+package pgxuuid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func TestEncodeScan(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	m := pgtype.NewMap()
+	Register(m)
+	id := uuid.MustGen()
+
+	// binary format is the 16 bytes of the UUID
+	buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, id, nil)
+	assert.NoErr("Encode binary", err)
+	assert.Eq("binary wire data", buf, id[:])
+	var id2 uuid.UUID
+	assert.NoErr("Scan binary", m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, buf, &id2))
+	assert.Eq("binary round trip", id2, id)
+
+	// text format is the standard hex form
+	buf, err = m.Encode(pgtype.UUIDOID, pgtype.TextFormatCode, uuid.Max, nil)
+	assert.NoErr("Encode text", err)
+	assert.Eq("text wire data", string(buf), "ffffffff-ffff-ffff-ffff-ffffffffffff")
+	assert.NoErr("Scan text", m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, buf, &id2))
+	assert.Eq("text round trip", id2, uuid.Max)
+
+	assert.Err("Scan NULL", "uuid: cannot scan NULL", m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &id2))
+
+	// DecodeValue yields uuid.UUID
+	typ, _ := m.TypeForOID(pgtype.UUIDOID)
+	v, err := typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, id[:])
+	assert.NoErr("DecodeValue", err)
+	assert.Eq("DecodeValue", v, id)
+}
+
+func TestArray(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	m := pgtype.NewMap()
+	Register(m)
+	ids := []uuid.UUID{uuid.MustGen(), uuid.Min, uuid.Max}
+
+	buf, err := m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, ids, nil)
+	assert.NoErr("Encode array", err)
+	var ids2 []uuid.UUID
+	assert.NoErr("Scan array", m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, buf, &ids2))
+	assert.Ok("array round trip", reflect.DeepEqual(ids2, ids))
+
+	typ, _ := m.TypeForOID(pgtype.UUIDArrayOID)
+	v, err := typ.Codec.DecodeValue(m, pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, buf)
+	assert.NoErr("DecodeValue array", err)
+	assert.Ok("DecodeValue array",
+		reflect.DeepEqual(v, []any{ids[0], ids[1], ids[2]}), "got %v", v)
+}
@@ -0,0 +1,138 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// PrefixedIDMaxPrefixLen is the maximum length of the prefix of a PrefixedID
+const PrefixedIDMaxPrefixLen = 32
+
+// PrefixedID is a UUID with a type prefix, like "usr_1cd4pgZR4ZDRaRq3JYcWy".
+// The string representation is the prefix, an underscore and the UUID as returned by
+// String(). Prefixes are 1-32 ASCII letters, digits and underscores, which makes prefixes
+// like "sk_live" possible, since the UUID part never contains an underscore. Underscores
+// may only separate letters and digits, so prefixes can't start or end with one or
+// contain two in a row.
+//
+// PrefixedID implements encoding.TextMarshaler and encoding.TextUnmarshaler (for JSON, YAML
+// etc) as well as sql.Scanner and driver.Valuer, storing the string representation.
+// When unmarshaling or scanning into a PrefixedID with a non-empty Prefix, the decoded prefix
+// must match it, e.g.
+//
+//	id := uuid.PrefixedID{Prefix: "usr"}
+//	err := json.Unmarshal(data, &id) // fails for "ord_1cd4pgZR4ZDRaRq3JYcWy"
+type PrefixedID struct {
+	Prefix string
+	UUID   UUID
+}
+
+// NewPrefixedID returns a PrefixedID after validating prefix
+func NewPrefixedID(prefix string, id UUID) (PrefixedID, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return PrefixedID{}, err
+	}
+	return PrefixedID{prefix, id}, nil
+}
+
+// ParsePrefixedID decodes the string representation of a PrefixedID
+func ParsePrefixedID(s string) (PrefixedID, error) {
+	i := strings.LastIndexByte(s, '_')
+	if i < 0 {
+		return PrefixedID{}, fmt.Errorf("uuid: missing prefix in %q", s)
+	}
+	if err := validatePrefix(s[:i]); err != nil {
+		return PrefixedID{}, err
+	}
+	id, err := Parse(s[i+1:])
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	return PrefixedID{s[:i], id}, nil
+}
+
+// ParseWithPrefix decodes the string representation of a PrefixedID and returns its UUID.
+// An error is returned if the prefix of s is not prefix.
+func ParseWithPrefix(prefix, s string) (UUID, error) {
+	p := PrefixedID{Prefix: prefix}
+	err := p.decode(s)
+	return p.UUID, err
+}
+
+// String returns the string representation of p, e.g. "usr_1cd4pgZR4ZDRaRq3JYcWy"
+func (p PrefixedID) String() string {
+	var buf [PrefixedIDMaxPrefixLen + 1 + StringMaxLen]byte
+	return string(p.appendText(buf[:0]))
+}
+
+func (p PrefixedID) appendText(b []byte) []byte {
+	var buf [StringMaxLen]byte
+	n := p.UUID.EncodeString(buf[:])
+	b = append(b, p.Prefix...)
+	b = append(b, '_')
+	return append(b, buf[n:]...)
+}
+
+// decode sets p to the decoded value of s, checking that the prefix matches p.Prefix
+// if p.Prefix is not empty
+func (p *PrefixedID) decode(s string) error {
+	r, err := ParsePrefixedID(s)
+	if err != nil {
+		return err
+	}
+	if p.Prefix != "" && r.Prefix != p.Prefix {
+		return fmt.Errorf("uuid: unexpected prefix %q (expected %q)", r.Prefix, p.Prefix)
+	}
+	*p = r
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// An error is returned if the prefix is invalid.
+func (p PrefixedID) MarshalText() ([]byte, error) {
+	if err := validatePrefix(p.Prefix); err != nil {
+		return nil, err
+	}
+	return p.appendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (p *PrefixedID) UnmarshalText(text []byte) error {
+	return p.decode(string(text))
+}
+
+// Value implements the driver.Valuer interface, storing the string representation
+func (p PrefixedID) Value() (driver.Value, error) {
+	if err := validatePrefix(p.Prefix); err != nil {
+		return nil, err
+	}
+	return p.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting string and []byte values
+func (p *PrefixedID) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return p.decode(src)
+	case []byte:
+		return p.decode(string(src))
+	}
+	return fmt.Errorf("uuid: cannot scan %T into PrefixedID", src)
+}
+
+func validatePrefix(prefix string) error {
+	if len(prefix) == 0 || len(prefix) > PrefixedIDMaxPrefixLen {
+		return fmt.Errorf("uuid: invalid prefix length %d", len(prefix))
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; !isBase62(c) && c != '_' {
+			return fmt.Errorf("uuid: invalid character %q in prefix %q", c, prefix)
+		}
+	}
+	// underscores only separate words, keeping the string form unambiguous
+	if prefix[0] == '_' || prefix[len(prefix)-1] == '_' || strings.Contains(prefix, "__") {
+		return fmt.Errorf("uuid: misplaced underscore in prefix %q", prefix)
+	}
+	return nil
+}
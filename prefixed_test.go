@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestPrefixedID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+
+	p, err := NewPrefixedID("usr", id)
+	assert.NoErr("NewPrefixedID", err)
+	s := p.String()
+	assert.Eq("String", s, "usr_"+id.String())
+
+	p2, err := ParsePrefixedID(s)
+	assert.NoErr("ParsePrefixedID", err)
+	assert.Eq("ParsePrefixedID", p2, p)
+
+	p3, err := ParsePrefixedID("sk_live_" + id.String())
+	assert.NoErr("ParsePrefixedID with underscore", err)
+	assert.Eq("ParsePrefixedID with underscore", p3, PrefixedID{"sk_live", id})
+
+	id2, err := ParseWithPrefix("usr", s)
+	assert.NoErr("ParseWithPrefix", err)
+	assert.Eq("ParseWithPrefix", id2, id)
+	_, err = ParseWithPrefix("ord", s)
+	assert.Err("ParseWithPrefix mismatch", `unexpected prefix "usr" (expected "ord")`, err)
+
+	for _, s := range []string{id.String(), "_" + id.String(), "usr_", "usr_abc-def", "us-r_" + id.String()} {
+		_, err := ParsePrefixedID(s)
+		assert.Ok("ParsePrefixedID(%q) fails", err != nil, s)
+	}
+	_, err = NewPrefixedID("", id)
+	assert.Err("NewPrefixedID empty", "invalid prefix length 0", err)
+	_, err = NewPrefixedID("a b", id)
+	assert.Err("NewPrefixedID invalid", `invalid character ' ' in prefix "a b"`, err)
+	for _, prefix := range []string{"_", "__", "_usr", "usr_", "sk__live", "a_b_"} {
+		_, err = NewPrefixedID(prefix, id)
+		assert.Err("NewPrefixedID("+prefix+")", "misplaced underscore in prefix", err)
+		_, err = ParsePrefixedID(prefix + "_" + id.String())
+		assert.Err("ParsePrefixedID("+prefix+"_...)", "misplaced underscore in prefix", err)
+	}
+	_, err = NewPrefixedID("a_b_c", id)
+	assert.NoErr("NewPrefixedID with underscores", err)
+}
+
+func TestPrefixedIDEncoding(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	p := PrefixedID{"usr", MustGen()}
+
+	type User struct {
+		ID PrefixedID `json:"id"`
+	}
+	data, err := json.Marshal(User{p})
+	assert.NoErr("json.Marshal", err)
+	assert.Eq("json.Marshal", string(data), `{"id":"`+p.String()+`"}`)
+	var u User
+	assert.NoErr("json.Unmarshal", json.Unmarshal(data, &u))
+	assert.Eq("json.Unmarshal", u.ID, p)
+
+	u = User{PrefixedID{Prefix: "ord"}}
+	assert.Err("json.Unmarshal mismatch", "unexpected prefix", json.Unmarshal(data, &u))
+
+	_, err = json.Marshal(User{})
+	assert.Err("json.Marshal invalid", "invalid prefix length 0", err)
+
+	v, err := p.Value()
+	assert.NoErr("Value", err)
+	assert.Eq("Value", v, p.String())
+	var p2 PrefixedID
+	assert.NoErr("Scan string", p2.Scan(p.String()))
+	assert.Eq("Scan string", p2, p)
+	p2 = PrefixedID{}
+	assert.NoErr("Scan []byte", p2.Scan([]byte(p.String())))
+	assert.Eq("Scan []byte", p2, p)
+	assert.Err("Scan int", "cannot scan int64 into PrefixedID", p2.Scan(int64(1)))
+}
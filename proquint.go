@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// Proquint returns the UUID as eight pronounceable five-letter words separated by hyphens,
+// e.g. "bafid-tapoj-babab-babab-jarut-zubal-fakaf-kijat". Each word (a "proquint", see
+// https://arxiv.org/abs/0901.4016) encodes 16 bits as alternating consonants and vowels,
+// making the form suitable for reading IDs aloud or over the phone.
+// Use FromProquint to decode it.
+func (id UUID) Proquint() string {
+	var buf [8*6 - 1]byte
+	for i := 0; i < 8; i++ {
+		v := uint16(id[i*2])<<8 | uint16(id[i*2+1])
+		b := buf[i*6:]
+		b[0] = proquintConsonants[v>>12]
+		b[1] = proquintVowels[v>>10&3]
+		b[2] = proquintConsonants[v>>6&15]
+		b[3] = proquintVowels[v>>4&3]
+		b[4] = proquintConsonants[v&15]
+		if i < 7 {
+			b[5] = '-'
+		}
+	}
+	return string(buf[:])
+}
+
+// FromProquint decodes a string returned by Proquint.
+// Letters are accepted in upper- as well as lowercase.
+func FromProquint(s string) (UUID, error) {
+	var id UUID
+	if len(s) != 8*6-1 {
+		return id, fmt.Errorf("uuid: invalid proquint length %d", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if i%6 == 5 {
+			if c != '-' {
+				return UUID{}, &InvalidCharError{Char: c, Offset: i}
+			}
+			continue
+		}
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		var v, bits int
+		if i%6%2 == 0 {
+			v, bits = strings.IndexByte(proquintConsonants, c), 4
+		} else {
+			v, bits = strings.IndexByte(proquintVowels, c), 2
+		}
+		if v < 0 {
+			return UUID{}, &InvalidCharError{Char: s[i], Offset: i}
+		}
+		word := i / 6
+		w := uint16(id[word*2])<<8 | uint16(id[word*2+1])
+		w = w<<bits | uint16(v)
+		id[word*2], id[word*2+1] = byte(w>>8), byte(w)
+	}
+	return id, nil
+}
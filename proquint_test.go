@@ -0,0 +1,52 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestProquint(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 127.0.0.1 and 63.84.220.193 from the proquint specification
+	id := UUID{0x7f, 0x00, 0x00, 0x01, 0x3f, 0x54, 0xdc, 0xc1}
+	assert.Eq("Proquint", id.Proquint(),
+		"lusab-babad-gutih-tugad-babab-babab-babab-babab")
+	assert.Eq("Proquint Min", Min.Proquint(), strings.Repeat("babab-", 7)+"babab")
+	assert.Eq("Proquint Max", Max.Proquint(), strings.Repeat("zuzuz-", 7)+"zuzuz")
+
+	for _, id := range []UUID{id, Min, Max, MustGen(), MustGen()} {
+		s := id.Proquint()
+		id2, err := FromProquint(s)
+		assert.NoErr("FromProquint "+s, err)
+		assert.Eq("FromProquint "+s, id2, id)
+		id2, err = FromProquint(strings.ToUpper(s))
+		assert.NoErr("FromProquint uppercase", err)
+		assert.Eq("FromProquint uppercase", id2, id)
+	}
+}
+
+func TestFromProquintErrors(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	s := MustGen().Proquint()
+	_, err := FromProquint(s[1:])
+	assert.Err("length", "invalid proquint length 46", err)
+
+	var cerr *InvalidCharError
+	for _, c := range []struct {
+		s      string
+		offset int
+	}{
+		{"c" + s[1:], 0},         // c is not a proquint consonant
+		{s[:1] + "b" + s[2:], 1}, // consonant in place of a vowel
+		{s[:2] + "a" + s[3:], 2}, // vowel in place of a consonant
+		{s[:5] + " " + s[6:], 5}, // separator
+		{s[:46] + "\xff", 46},
+	} {
+		_, err := FromProquint(c.s)
+		assert.Ok("InvalidCharError "+c.s, errors.As(err, &cerr) && cerr.Offset == c.offset)
+	}
+}
@@ -0,0 +1,31 @@
+package uuid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// Seed returns a 32-byte seed derived from the UUID, the SHA-256 hash of its bytes.
+// Different UUIDs yield unrelated seeds, even when they differ in a single bit (like two
+// UUIDs generated in the same millisecond), and the derivation will not change in future
+// versions. This allows keying reproducible pseudo-random decisions off an entity's ID,
+// like jitter or sampling in simulations.
+//
+// The seed is as predictable as the UUID; don't use it for secrets.
+func (id UUID) Seed() [32]byte {
+	return sha256.Sum256(id[:])
+}
+
+// NewChaCha8 returns a math/rand/v2 ChaCha8 generator seeded with Seed(), e.g.
+//
+//	r := rand.New(id.NewChaCha8())
+func (id UUID) NewChaCha8() *rand.ChaCha8 {
+	return rand.NewChaCha8(id.Seed())
+}
+
+// NewPCG returns a math/rand/v2 PCG generator seeded with the first 16 bytes of Seed()
+func (id UUID) NewPCG() *rand.PCG {
+	seed := id.Seed()
+	return rand.NewPCG(binary.BigEndian.Uint64(seed[0:8]), binary.BigEndian.Uint64(seed[8:16]))
+}
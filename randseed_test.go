@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestSeed(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustFromString("14dkqb8qQuruFQRFEEaUf")
+
+	// seeds must never change as they key persisted or reproduced decisions
+	seed := id.Seed()
+	assert.Eq("Seed", hex.EncodeToString(seed[:]),
+		"8de9c6f73b46d1441788ca91d9df22a098fb639c1139f14ac6285da82cba4613")
+	assert.Eq("NewChaCha8", id.NewChaCha8().Uint64(), uint64(0x50c43e8b09e5df1b))
+	assert.Eq("NewPCG", id.NewPCG().Uint64(), uint64(0xf523d9763b2cb511))
+
+	// reproducible
+	r1, r2 := rand.New(id.NewPCG()), rand.New(id.NewPCG())
+	for i := 0; i < 10; i++ {
+		assert.Eq("PCG sequence", r1.IntN(1000), r2.IntN(1000))
+	}
+
+	// neighbouring UUIDs yield unrelated seeds
+	id2 := id
+	id2[15] ^= 1
+	seed2 := id2.Seed()
+	same := 0
+	for i := range seed {
+		if seed[i] == seed2[i] {
+			same++
+		}
+	}
+	assert.Ok("unrelated seeds", same < 8)
+}
@@ -0,0 +1,42 @@
+package uuid
+
+// Reader is an io.Reader producing a stream of newly generated UUIDs, 16 bytes each,
+// one after another. This is useful for load testing and for piping synthetic keys into
+// bulk loaders, e.g. with io.CopyN(w, &uuid.Reader{}, 16*count).
+//
+// The stream stays aligned on UUID boundaries regardless of the size of the buffers passed
+// to Read: a UUID which doesn't fit in a buffer is continued by the next call to Read.
+// The zero value is ready to use. A Reader is not safe for concurrent use.
+type Reader struct {
+	// Generator generates the UUIDs. If nil, the UUIDs are generated with Gen.
+	Generator *Generator
+
+	buf  UUID // UUID partially returned by the last call to Read
+	tail int  // number of bytes at the end of buf not yet returned
+}
+
+// Read fills b with generated UUIDs. It only returns an error if generating a UUID fails.
+func (r *Reader) Read(b []byte) (n int, err error) {
+	if r.tail > 0 {
+		n = copy(b, r.buf[len(r.buf)-r.tail:])
+		r.tail -= n
+	}
+	for n < len(b) {
+		var id UUID
+		if r.Generator != nil {
+			id, err = r.Generator.Gen()
+		} else {
+			id, err = Gen()
+		}
+		if err != nil {
+			return n, err
+		}
+		c := copy(b[n:], id[:])
+		if c < len(id) {
+			r.buf = id
+			r.tail = len(id) - c
+		}
+		n += c
+	}
+	return n, nil
+}
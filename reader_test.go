@@ -0,0 +1,60 @@
+package uuid
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestReader(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+	start := time.Unix(1700000000, 0)
+
+	// reading in odd-sized chunks yields the same stream as reading whole UUIDs
+	r := &Reader{Generator: NewSeeded(1, start)}
+	var buf bytes.Buffer
+	chunk := make([]byte, 7)
+	for buf.Len() < 16*10 {
+		n, err := r.Read(chunk)
+		assert.NoErr("Read", err)
+		assert.Eq("Read length", n, len(chunk))
+		buf.Write(chunk[:n])
+	}
+	g := NewSeeded(1, start)
+	for i := 0; i < 10; i++ {
+		id, err := g.Gen()
+		assert.NoErr("Gen", err)
+		assert.Eq("UUID", buf.Next(16), id[:])
+	}
+
+	// default generator
+	b := make([]byte, 16*100)
+	n, err := io.ReadFull(&Reader{}, b)
+	assert.NoErr("ReadFull", err)
+	assert.Eq("ReadFull", n, len(b))
+	seen := map[UUID]bool{}
+	var prev UUID
+	for i := 0; i < len(b); i += 16 {
+		id := UUID(b[i : i+16])
+		assert.Ok("unique", !seen[id])
+		assert.Ok("time", id.Time().Sub(time.Now()).Abs() < time.Minute)
+		assert.Ok("ordered", id.Time().Compare(prev.Time()) >= 0)
+		seen[id], prev = true, id
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+	r := &Reader{Generator: &Generator{Rand: &flakyReader{n: 1}}}
+	n, err := r.Read(make([]byte, 40))
+	assert.Err("error", "entropy unavailable", err)
+	assert.Eq("n", n, 0)
+	n, err = r.Read(make([]byte, 40))
+	assert.NoErr("recovered", err)
+	assert.Eq("n", n, 40)
+}
@@ -0,0 +1,50 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// gregorianOffset is the number of 100-nanosecond intervals between the start of the
+// Gregorian calendar (1582-10-15), the epoch of RFC 9562 version 1 and 6 UUIDs, and the
+// Unix epoch
+const gregorianOffset = 0x01b21dd213814000
+
+// FromRFCTime returns a UUID for the RFC 9562 (RFC 4122) version 1 or version 6 UUID u,
+// translating the 60-bit Gregorian timestamp of u to the layout of this package.
+// Use this to re-key legacy time-based identifiers into a time-sorted keyspace; u can be
+// passed as a [16]byte conversion of e.g. a google/uuid or gofrs/uuid UUID.
+//
+// The UUID gets the millisecond timestamp of u, bytes 6-7 hold the remaining sub-millisecond
+// part of the timestamp in 100-nanosecond units (0-9999) and bytes 8-15 are copied
+// verbatim from u (the variant, clock sequence and node.) The mapping is deterministic and
+// maintains the time order of UUIDs.
+//
+// An error is returned if u is not a version 1 or 6 UUID, or if its timestamp is outside
+// the range of this package's UUIDs (2020-09-13 to 2156-10-20.)
+func FromRFCTime(u [16]byte) (UUID, error) {
+	var ts uint64
+	switch u[6] >> 4 {
+	case 1:
+		ts = uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+			uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+	case 6:
+		ts = uint64(u[0])<<52 | uint64(u[1])<<44 | uint64(u[2])<<36 | uint64(u[3])<<28 |
+			uint64(u[4])<<20 | uint64(u[5])<<12 | uint64(u[6]&0x0f)<<8 | uint64(u[7])
+	default:
+		return UUID{}, fmt.Errorf("uuid: unsupported RFC UUID version %d", u[6]>>4)
+	}
+	if ts < gregorianOffset {
+		return UUID{}, fmt.Errorf("uuid: RFC UUID timestamp out of range")
+	}
+	unix := ts - gregorianOffset // 100ns units since the Unix epoch
+	ms, sub := int64(unix/10000), uint16(unix%10000)
+	if ms/1000 < idEpochBase || ms/1000-idEpochBase > 0xffffffff {
+		return UUID{}, fmt.Errorf("uuid: RFC UUID timestamp out of range")
+	}
+	id := New(ms/1000, int(ms%1000)*int(time.Millisecond), nil)
+	id[6] = byte(sub >> 8)
+	id[7] = byte(sub)
+	copy(id[8:], u[8:])
+	return id, nil
+}
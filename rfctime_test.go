@@ -0,0 +1,45 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestFromRFCTime(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// test vectors from RFC 9562 appendix A, both at 2022-02-22 19:22:22 UTC
+	v1, _ := FromHex("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6, _ := FromHex("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	tm := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, u := range []UUID{v1, v6} {
+		id, err := FromRFCTime(u)
+		assert.NoErr("FromRFCTime", err)
+		assert.Eq("time", id.Time().UTC(), tm)
+		assert.Eq("sub-millisecond", id[6:8], []byte{0, 0})
+		assert.Eq("clock sequence and node", id[8:], u[8:])
+	}
+
+	// sub-millisecond precision orders UUIDs within the same millisecond
+	v6b := v6
+	v6b[7] = 0x01 // +100ns
+	a, _ := FromRFCTime(v6)
+	b, err := FromRFCTime(v6b)
+	assert.NoErr("FromRFCTime", err)
+	assert.Eq("same millisecond", b.Time(), a.Time())
+	assert.Eq("sub-millisecond", b[6:8], []byte{0, 1})
+	assert.Ok("order", bytes.Compare(a[:], b[:]) < 0)
+
+	v4, _ := FromHex("919108f7-52d1-4320-9bac-f847db4148a8")
+	_, err = FromRFCTime(v4)
+	assert.Err("version", "unsupported RFC UUID version 4", err)
+	v1old, _ := FromHex("00000000-0000-1000-8000-000000000000") // 1582
+	_, err = FromRFCTime(v1old)
+	assert.Err("before epoch", "out of range", err)
+	v1new, _ := FromHex("ffffffff-ffff-1fff-8000-000000000000") // 5236
+	_, err = FromRFCTime(v1new)
+	assert.Err("after end of epoch", "out of range", err)
+}
@@ -0,0 +1,56 @@
+package uuid
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// NewSeeded returns a Generator which produces a reproducible sequence of UUIDs.
+// Random bytes are derived from seed and the clock starts at start and advances by exactly
+// one millisecond for every UUID generated. Two Generators created with the same arguments
+// generate the same sequence of UUIDs.
+//
+// This is intended for tests, e.g. for golden files and snapshots involving UUIDs.
+// The UUIDs are predictable and must not be used where uniqueness matters.
+func NewSeeded(seed int64, start time.Time) *Generator {
+	clock := &steppingClock{next: start, step: time.Millisecond}
+	return &Generator{
+		Rand: &seededReader{r: rand.New(rand.NewPCG(uint64(seed), 0))},
+		Now:  clock.now,
+	}
+}
+
+// seededReader is a deterministic, concurrency-safe reader of pseudo-random bytes
+type seededReader struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (s *seededReader) Read(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < len(b); i += 8 {
+		v := s.r.Uint64()
+		for j := i; j < i+8 && j < len(b); j++ {
+			b[j] = byte(v)
+			v >>= 8
+		}
+	}
+	return len(b), nil
+}
+
+// steppingClock returns a time which advances by step every time it is read
+type steppingClock struct {
+	mu   sync.Mutex
+	next time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.next
+	c.next = t.Add(c.step)
+	return t
+}
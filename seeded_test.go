@@ -0,0 +1,32 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestNewSeeded(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	start := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	g1 := NewSeeded(123, start)
+	g2 := NewSeeded(123, start)
+	g3 := NewSeeded(124, start)
+	for i := 0; i < 100; i++ {
+		id1, err := g1.Gen()
+		assert.NoErr("Gen", err)
+		id2, _ := g2.Gen()
+		id3, _ := g3.Gen()
+		assert.Eq("same seed yields same sequence", id1, id2)
+		assert.Ok("different seed yields different sequence", id1 != id3)
+		assert.Eq("time advances by one millisecond",
+			id1.Time().UTC(), start.Add(time.Duration(i)*time.Millisecond))
+	}
+
+	// guard against accidental changes to the sequence, which would break users' golden files
+	id, _ := NewSeeded(1, start).Gen()
+	assert.Eq("golden", id.String(), "14dkqb8qQul3K72FKf79t")
+}
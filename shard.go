@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// ShardOf maps id onto one of n partitions, returning a value in the range [0, n).
+// Only the random portion (bytes 6-15) of the UUID is used, not the timestamp, so that
+// UUIDs generated at around the same time are spread out over all partitions rather than
+// creating a "hot" partition. The result is stable: the same id and n always yield the
+// same partition. ShardOf panics if n <= 0.
+func (id UUID) ShardOf(n int) int {
+	if n <= 0 {
+		panic("uuid: invalid argument to ShardOf")
+	}
+	// multiply-shift maps the 64-bit hash onto [0, n) without the bias of modulo
+	hi, _ := bits.Mul64(id.randomHash(), uint64(n))
+	return int(hi)
+}
+
+// randomHash returns a well-distributed 64-bit hash of the random portion of id.
+// The bytes are mixed so that UUIDs with little entropy in some bytes (e.g. made with
+// New and short random data) are still spread out.
+func (id UUID) randomHash() uint64 {
+	x := binary.BigEndian.Uint64(id[8:16])
+	x ^= uint64(binary.BigEndian.Uint16(id[6:8])) * 0x9e3779b97f4a7c15
+	return mix64(x)
+}
+
+// Hash returns a well-distributed 64-bit hash of id, for use with hash tables,
+// consistent-hashing rings and the like. Unlike ShardOf, all 16 bytes of id contribute to
+// the hash, so UUIDs that only differ in their timestamp have different hashes.
+// The result is stable across processes and versions of this package.
+func (id UUID) Hash() uint64 {
+	x := binary.BigEndian.Uint64(id[8:16])
+	x ^= binary.BigEndian.Uint64(id[0:8]) * 0x9e3779b97f4a7c15
+	return mix64(x)
+}
+
+// mix64 is the splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
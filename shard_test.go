@@ -0,0 +1,59 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestShardOf(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id := MustGen()
+	assert.Eq("stable", id.ShardOf(16), id.ShardOf(16))
+	assert.Eq("n=1", id.ShardOf(1), 0)
+
+	// timestamp does not affect the shard
+	id2 := New(1700000000, 0, id[6:])
+	assert.Eq("timestamp independent", id2.ShardOf(1000), id.ShardOf(1000))
+
+	// roughly uniform distribution, also for IDs with little entropy
+	const n = 8
+	const count = 8000
+	var counts [n]int
+	for i := 0; i < count; i++ {
+		id := New(1603212345, 0, []byte{0, 0, 0, 0, 0, 0, 0, 0, byte(i >> 8), byte(i)})
+		s := id.ShardOf(n)
+		assert.Ok("in range", s >= 0 && s < n)
+		counts[s]++
+	}
+	for i, c := range counts {
+		assert.Ok("shard %d count %d is within 20%% of expected", c > count/n*8/10 && c < count/n*12/10, i, c)
+	}
+
+	assert.Panic("invalid argument", func() { id.ShardOf(0) })
+}
+
+func TestHash(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id := MustGen()
+	assert.Eq("stable", id.Hash(), id.Hash())
+	assert.Eq("golden", FromString("14dkqb8qQuruFQRFEEaUf").Hash(), uint64(0x534df35c03677d7c))
+
+	// timestamp affects the hash
+	id2 := New(1700000000, 0, id[6:])
+	assert.Ok("timestamp dependent", id2.Hash() != id.Hash())
+
+	// IDs with little entropy are spread out over the low bits
+	const n = 8
+	const count = 8000
+	var counts [n]int
+	for i := 0; i < count; i++ {
+		id := New(1603212345+int64(i), 0, nil)
+		counts[id.Hash()%n]++
+	}
+	for i, c := range counts {
+		assert.Ok("bucket %d count %d is within 20%% of expected", c > count/n*8/10 && c < count/n*12/10, i, c)
+	}
+}
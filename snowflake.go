@@ -0,0 +1,42 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// FromSnowflake returns a UUID for the Twitter-style snowflake ID s, whose timestamp is
+// relative to epoch (e.g. 2010-11-04 01:42:54.657 UTC for Twitter.)
+//
+// Snowflake IDs are 63 bits: a 41-bit millisecond timestamp followed by 22 bits of
+// machine ID and sequence number. The UUID gets the same timestamp and the 22 low bits are
+// stored in the top of bytes 6-8, with the remaining bytes set to zero. The mapping is
+// deterministic, so the same snowflake always maps to the same UUID, and order is maintained.
+// An error is returned if s is negative or its timestamp can't be represented by a UUID.
+func FromSnowflake(s int64, epoch time.Time) (UUID, error) {
+	if s < 0 {
+		return UUID{}, fmt.Errorf("uuid: invalid snowflake ID %d", s)
+	}
+	ms := s>>22 + unixMilli(epoch)
+	sec := floorDiv(ms, 1000)
+	if sec < idEpochBase || sec-idEpochBase > 0xffffffff {
+		return UUID{}, fmt.Errorf("uuid: snowflake ID %d timestamp out of range", s)
+	}
+	low := uint32(s&0x3fffff) << 2
+	return New(sec, int(floorMod(ms, 1000))*int(time.Millisecond),
+		[]byte{byte(low >> 16), byte(low >> 8), byte(low)}), nil
+}
+
+// ToSnowflake returns a snowflake ID with the timestamp of id relative to epoch
+// (see FromSnowflake.) The 22 low bits are taken from the top of bytes 6-8 and the rest of
+// the UUID is discarded, so that ToSnowflake(FromSnowflake(s)) == s.
+// An error is returned if the timestamp is before epoch or too far after it to fit in
+// 41 bits (about 69 years.)
+func (id UUID) ToSnowflake(epoch time.Time) (int64, error) {
+	ms := unixMilli(id.Time()) - unixMilli(epoch)
+	if ms < 0 || ms >= 1<<41 {
+		return 0, fmt.Errorf("uuid: timestamp out of range for snowflake ID with epoch %v", epoch)
+	}
+	low := (uint32(id[6])<<16 | uint32(id[7])<<8 | uint32(id[8])) >> 2
+	return ms<<22 | int64(low), nil
+}
@@ -0,0 +1,48 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+// twitterEpoch is the epoch of Twitter snowflake IDs
+var twitterEpoch = time.Unix(1288834974, 657*int64(time.Millisecond))
+
+func TestSnowflake(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 1541815603606036480 = 2022-06-28 16:07:40.105 UTC
+	const s = int64(1541815603606036480)
+	id, err := FromSnowflake(s, twitterEpoch)
+	assert.NoErr("FromSnowflake", err)
+	assert.Eq("FromSnowflake time", id.Time().UTC(),
+		time.Date(2022, 6, 28, 16, 7, 40, 105*int(time.Millisecond), time.UTC))
+	assert.Eq("FromSnowflake bytes 9-15 are zero", id[9:], make([]byte, 7))
+	id2, _ := FromSnowflake(s, twitterEpoch)
+	assert.Eq("deterministic", id2, id)
+
+	s2, err := id.ToSnowflake(twitterEpoch)
+	assert.NoErr("ToSnowflake", err)
+	assert.Eq("ToSnowflake(FromSnowflake())", s2, s)
+
+	// order is maintained
+	a, _ := FromSnowflake(s+1, twitterEpoch)
+	b, _ := FromSnowflake(s+1<<22, twitterEpoch)
+	assert.Ok("order", string(id[:]) < string(a[:]) && string(a[:]) < string(b[:]))
+
+	// generated UUIDs
+	id3 := MustGen()
+	s3, err := id3.ToSnowflake(twitterEpoch)
+	assert.NoErr("ToSnowflake", err)
+	id4, _ := FromSnowflake(s3, twitterEpoch)
+	assert.Eq("timestamp survives round trip", id4.Time(), id3.Time())
+
+	_, err = FromSnowflake(-1, twitterEpoch)
+	assert.Err("negative", "invalid snowflake ID", err)
+	_, err = FromSnowflake(1, twitterEpoch) // 2010
+	assert.Err("before UUID epoch", "out of range", err)
+	_, err = id.ToSnowflake(time.Now().Add(time.Hour))
+	assert.Err("before snowflake epoch", "out of range", err)
+}
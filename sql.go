@@ -0,0 +1,80 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements the driver.Valuer interface, storing the UUID as its 16 bytes (see
+// MarshalBinary), which keeps the sort order of UUIDs in binary columns like BINARY(16) or
+// BLOB. Drivers send the bytes as binary data, not as a uuid, so for PostgreSQL uuid
+// columns use the pgxuuid module (or store HexHyphenated) instead.
+func (id UUID) Value() (driver.Value, error) {
+	return id.MarshalBinary()
+}
+
+// NullUUID is a UUID which may be NULL, implementing the sql.Scanner and driver.Valuer
+// interfaces. Use it as the destination of database/sql's Rows.Scan for UUID columns:
+//
+//	var id uuid.NullUUID
+//	err := db.QueryRow("SELECT id FROM users WHERE email = $1", email).Scan(&id)
+//
+// (UUID itself can't implement sql.Scanner since its Scan method implements fmt.Scanner.)
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements the sql.Scanner interface. The source format is detected by its length:
+//
+//	16 bytes        the 16 bytes of the UUID, as stored by Value
+//	1-22 chars      the base62 string representation, as returned by String()
+//	32 or 36 chars  hexadecimal, with or without hyphens (see FromHex)
+//
+// Sources can be either string or []byte. Strings are always read as text, while a []byte
+// of 16 bytes is always read as the bytes of the UUID, never as a 16-character base62
+// string. Scan base62 strings of columns whose driver returns []byte into a string first.
+// NULL sets Valid to false.
+func (n *NullUUID) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		*n = NullUUID{}
+		return nil
+	case []byte:
+		if len(src) == 16 {
+			*n = NullUUID{UUID(src), true}
+			return nil
+		}
+		b = src
+	case string:
+		b = []byte(src)
+	default:
+		return fmt.Errorf("uuid: cannot scan %T into UUID", src)
+	}
+	var id UUID
+	switch len(b) {
+	case 32, 36:
+		var err error
+		if id, err = FromHex(string(b)); err != nil {
+			return err
+		}
+	default:
+		if len(b) > StringMaxLen {
+			return fmt.Errorf("uuid: cannot scan %d-byte value into UUID", len(b))
+		}
+		if err := id.DecodeStringStrict(b); err != nil {
+			return err
+		}
+	}
+	*n = NullUUID{id, true}
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning nil if n is not Valid
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
@@ -0,0 +1,57 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestSQL(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	var _ driver.Valuer = id
+	var _ sql.Scanner = (*NullUUID)(nil)
+
+	v, err := id.Value()
+	assert.NoErr("Value", err)
+	assert.Eq("Value", v, id[:])
+
+	v, err = NullUUID{}.Value()
+	assert.NoErr("NullUUID Value nil", err)
+	assert.Eq("NullUUID Value nil", v, nil)
+	v, err = NullUUID{id, true}.Value()
+	assert.NoErr("NullUUID Value", err)
+	assert.Eq("NullUUID Value", v, id[:])
+
+	hex := id.Hex()
+	rfc := id.HexHyphenated()
+	for _, src := range []any{
+		id[:], id.String(), []byte(id.String()), id.StringPadded(), hex, []byte(rfc),
+	} {
+		n := NullUUID{}
+		assert.NoErr("Scan", n.Scan(src))
+		assert.Ok("Scan %q", n.Valid && n.UUID == id, src)
+	}
+
+	// a 16-character base62 string is text, while 16 bytes are the bytes of a UUID
+	var short UUID
+	assert.NoErr("DecodeString", short.DecodeStringStrict([]byte("DEMOabcdefghijkl")))
+	assert.Eq("16-char string", short.String(), "DEMOabcdefghijkl")
+	n := NullUUID{}
+	assert.NoErr("Scan 16-char string", n.Scan(short.String()))
+	assert.Eq("Scan 16-char string", n.UUID, short)
+	assert.NoErr("Scan 16 bytes", n.Scan([]byte(short.String())))
+	assert.Eq("Scan 16 bytes", n.UUID[:], []byte(short.String()))
+
+	n = NullUUID{id, true}
+	assert.NoErr("Scan nil", n.Scan(nil))
+	assert.Eq("Scan nil", n, NullUUID{})
+
+	assert.Err("Scan int", "cannot scan int64 into UUID", n.Scan(int64(1)))
+	assert.Err("Scan length", "cannot scan 24-byte value", n.Scan(make([]byte, 24)))
+	assert.Err("Scan invalid base62", "invalid character", n.Scan("abc-def"))
+	assert.Err("Scan invalid hex", "invalid hex", n.Scan("x"+hex[1:]))
+	assert.Err("Scan empty", "invalid string length 0", n.Scan(""))
+}
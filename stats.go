@@ -0,0 +1,76 @@
+package uuid
+
+import "sync/atomic"
+
+// Stats holds process-wide counters of UUID generation, as returned by ReadStats.
+// The counters only ever increase, making them suitable for monitoring systems which
+// compute rates, e.g. via expvar:
+//
+//	expvar.Publish("uuid", expvar.Func(func() any { return uuid.ReadStats() }))
+//
+// or as Prometheus counters:
+//
+//	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+//		Name: "uuid_entropy_failures_total",
+//	}, func() float64 { return float64(uuid.ReadStats().EntropyFailures) }))
+type Stats struct {
+	// Generated is the number of UUIDs generated by Gen, GenFrom, GenOpaque and Generators
+	Generated uint64
+
+	// EntropyFailures is the number of times reading random bytes failed, including
+	// failed attempts which were retried (see EntropyRetry)
+	EntropyFailures uint64
+
+	// EntropyFallbacks is the number of times a Generator used its fallback pseudo-random
+	// generator because reading random bytes failed (see EntropyFallback)
+	EntropyFallbacks uint64
+}
+
+var stats struct {
+	generated        atomic.Uint64
+	entropyFailures  atomic.Uint64
+	entropyFallbacks atomic.Uint64
+}
+
+// ReadStats returns the current values of the generation counters
+func ReadStats() Stats {
+	return Stats{
+		Generated:        stats.generated.Load(),
+		EntropyFailures:  stats.entropyFailures.Load(),
+		EntropyFallbacks: stats.entropyFallbacks.Load(),
+	}
+}
+
+// entropyObserver is the function set with SetEntropyObserver
+var entropyObserver atomic.Pointer[func(err error)]
+
+// SetEntropyObserver sets a function which is called with the error whenever reading random
+// bytes fails, anywhere in the process: in Gen, GenFrom, GenOpaque and Generators, including
+// failed attempts which are retried or replaced by a fallback (see EntropyPolicy.)
+// This allows alerting on degraded entropy regardless of how errors are handled.
+// f may be called concurrently and should return quickly. Pass nil to remove the observer.
+func SetEntropyObserver(f func(err error)) {
+	if f == nil {
+		entropyObserver.Store(nil)
+	} else {
+		entropyObserver.Store(&f)
+	}
+}
+
+// countGen updates the counters after generating a UUID, where err is the error from
+// reading random bytes
+func countGen(err error) {
+	if err != nil {
+		entropyFailed(err)
+	} else {
+		stats.generated.Add(1)
+	}
+}
+
+// entropyFailed records a failure to read random bytes
+func entropyFailed(err error) {
+	stats.entropyFailures.Add(1)
+	if f := entropyObserver.Load(); f != nil {
+		(*f)(err)
+	}
+}
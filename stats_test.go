@@ -0,0 +1,72 @@
+package uuid
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestStats(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	s0 := ReadStats()
+	MustGen()
+	GenOpaque()
+	var g Generator
+	g.Gen()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	for range g.Stream(ctx) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	// other tests may run in parallel, so only check lower bounds
+	s1 := ReadStats()
+	assert.Ok("Generated", s1.Generated-s0.Generated >= 6)
+
+	g = Generator{Rand: &flakyReader{n: 2}, EntropyPolicy: EntropyRetry}
+	g.Gen()
+	g = Generator{Rand: &flakyReader{n: 1}, EntropyPolicy: EntropyFallback}
+	g.Gen()
+	s2 := ReadStats()
+	assert.Ok("EntropyFailures", s2.EntropyFailures-s1.EntropyFailures >= 3)
+	assert.Ok("EntropyFallbacks", s2.EntropyFallbacks-s1.EntropyFallbacks >= 1)
+	assert.Ok("Generated", s2.Generated-s1.Generated >= 2)
+}
+
+func TestEntropyObserver(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	var mu sync.Mutex
+	var errs []error
+	SetEntropyObserver(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	defer SetEntropyObserver(nil)
+
+	var genErrs []error
+	g := Generator{
+		Rand:             &flakyReader{n: 1},
+		EntropyPolicy:    EntropyFallback,
+		OnEntropyFailure: func(err error) { genErrs = append(genErrs, err) },
+	}
+	_, err := g.Gen()
+	assert.NoErr("Gen with fallback", err)
+	assert.Eq("Generator.OnEntropyFailure calls", len(genErrs), 1)
+	assert.Err("Generator.OnEntropyFailure", "entropy unavailable", genErrs[0])
+
+	_, err = GenFrom(&flakyReader{n: 1})
+	assert.Err("GenFrom", "entropy unavailable", err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Ok("observer calls %d", len(errs) >= 2, len(errs))
+}
@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// ToTraceID returns id as an OpenTelemetry/W3C Trace Context trace ID, which can be passed
+// to trace.TraceID of go.opentelemetry.io/otel/trace as a conversion.
+//
+// The 16 bytes of id are used verbatim, so FromTraceID(id.ToTraceID()) == id and the trace
+// of a request can be looked up by its UUID. The last 8 bytes of a generated UUID are
+// random, satisfying the W3C recommendation that the right-most 7 bytes of a trace ID are
+// random. Note that the zero UUID (Min) is an invalid trace ID.
+func (id UUID) ToTraceID() [16]byte {
+	return id
+}
+
+// FromTraceID returns the UUID for an OpenTelemetry/W3C trace ID (see ToTraceID.)
+// The UUID's timestamp is only meaningful if the trace ID was created by ToTraceID.
+func FromTraceID(traceID [16]byte) UUID {
+	return traceID
+}
+
+// SpanID returns an OpenTelemetry/W3C span ID derived from id, which can be passed to
+// trace.SpanID of go.opentelemetry.io/otel/trace as a conversion.
+// The span ID is the last 8 bytes of id, which are random for generated UUIDs.
+// Note that an all-zero span ID is invalid, which is only the case when bytes 8-15 of id
+// are all zero.
+func (id UUID) SpanID() [8]byte {
+	return [8]byte(id[8:16])
+}
+
+// TraceParent returns a W3C Trace Context traceparent header value with id as the trace ID
+// and id.SpanID() as the parent ID, e.g.
+// "00-0031043902c939ce146c0bdba1407778-146c0bdba1407778-01".
+// The sampled flag is set if sampled is true.
+func (id UUID) TraceParent(sampled bool) string {
+	var buf [55]byte
+	spanID := id.SpanID()
+	copy(buf[:], "00-")
+	hex.Encode(buf[3:35], id[:])
+	buf[35] = '-'
+	hex.Encode(buf[36:52], spanID[:])
+	copy(buf[52:], "-00")
+	if sampled {
+		buf[54] = '1'
+	}
+	return string(buf[:])
+}
+
+// FromTraceParent returns the trace ID of a W3C Trace Context traceparent header value as
+// a UUID (see FromTraceID.) An error is returned if header is not a valid traceparent.
+// Versions other than 00 are accepted as specified by Trace Context, i.e. fields following
+// the flags are ignored.
+func FromTraceParent(header string) (UUID, error) {
+	var id UUID
+	if len(header) < 55 || header[2] != '-' || header[35] != '-' || header[52] != '-' ||
+		!isLowerHex(header[:2]) || !isLowerHex(header[3:35]) || !isLowerHex(header[36:52]) ||
+		!isLowerHex(header[53:55]) {
+		return id, fmt.Errorf("uuid: invalid traceparent %q", header)
+	}
+	if header[:2] == "ff" || (header[:2] == "00" && len(header) != 55) ||
+		(len(header) > 55 && header[55] != '-') {
+		return id, fmt.Errorf("uuid: invalid traceparent %q", header)
+	}
+	hex.Decode(id[:], []byte(header[3:35]))
+	if id == Min || header[36:52] == "0000000000000000" {
+		return UUID{}, fmt.Errorf("uuid: invalid traceparent %q (zero ID)", header)
+	}
+	return id, nil
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
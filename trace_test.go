@@ -0,0 +1,56 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestTraceID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	traceID := id.ToTraceID()
+	assert.Eq("ToTraceID", traceID[:], id[:])
+	assert.Eq("FromTraceID(ToTraceID())", FromTraceID(traceID), id)
+
+	spanID := id.SpanID()
+	assert.Eq("SpanID", spanID[:], id[8:])
+
+	assert.Eq("Min.SpanID", Min.SpanID(), [8]byte{})
+}
+
+func TestTraceParent(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id, _ := FromHex("0031043902c939ce146c0bdba1407778")
+	assert.Eq("TraceParent", id.TraceParent(false),
+		"00-0031043902c939ce146c0bdba1407778-146c0bdba1407778-00")
+	assert.Eq("TraceParent sampled", id.TraceParent(true),
+		"00-0031043902c939ce146c0bdba1407778-146c0bdba1407778-01")
+
+	id2 := MustGen()
+	id3, err := FromTraceParent(id2.TraceParent(true))
+	assert.NoErr("FromTraceParent", err)
+	assert.Eq("FromTraceParent(TraceParent())", id3, id2)
+
+	// example from the W3C Trace Context specification
+	id3, err = FromTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoErr("FromTraceParent", err)
+	assert.Eq("FromTraceParent", id3.Hex(), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	// future versions may append fields
+	_, err = FromTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xyz")
+	assert.NoErr("FromTraceParent future version", err)
+
+	for _, s := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xyz", // extra data in v00
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",     // invalid version
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",     // uppercase
+		"00-4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+	} {
+		_, err = FromTraceParent(s)
+		assert.Err("FromTraceParent invalid", "invalid traceparent", err)
+	}
+}
@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// TypedID is a UUID tagged with the type T, making IDs of different kinds of objects
+// distinct types which can't be mixed up, e.g.
+//
+//	type UserID = uuid.TypedID[User]
+//	type OrderID = uuid.TypedID[Order]
+//
+//	func CancelOrder(user UserID, order OrderID) error
+//
+// T is only used at compile time. TypedIDs have the same encodings as UUIDs: text (JSON,
+// YAML etc), binary, gob and SQL, and convert to and from UUID with TypedID[T](id) and UUID().
+type TypedID[T any] UUID
+
+// UUID returns the untyped UUID of id
+func (id TypedID[T]) UUID() UUID { return UUID(id) }
+
+// String returns the string representation of the UUID (see UUID.String)
+func (id TypedID[T]) String() string { return UUID(id).String() }
+
+// Time returns the time portion of the UUID (see UUID.Time)
+func (id TypedID[T]) Time() time.Time { return UUID(id).Time() }
+
+// LogValue implements the slog.LogValuer interface (see UUID.LogValue)
+func (id TypedID[T]) LogValue() slog.Value { return UUID(id).LogValue() }
+
+// MarshalText implements the encoding.TextMarshaler interface (see UUID.MarshalText)
+func (id TypedID[T]) MarshalText() ([]byte, error) { return UUID(id).MarshalText() }
+
+// AppendText implements the encoding.TextAppender interface (see UUID.AppendText)
+func (id TypedID[T]) AppendText(b []byte) ([]byte, error) { return UUID(id).AppendText(b) }
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface (see UUID.UnmarshalText)
+func (id *TypedID[T]) UnmarshalText(text []byte) error {
+	return (*UUID)(id).UnmarshalText(text)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting strings as well as the
+// legacy arrays of numbers (see UUID.UnmarshalJSON)
+func (id *TypedID[T]) UnmarshalJSON(data []byte) error {
+	return (*UUID)(id).UnmarshalJSON(data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface (see UUID.MarshalBinary)
+func (id TypedID[T]) MarshalBinary() ([]byte, error) { return UUID(id).MarshalBinary() }
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+// (see UUID.UnmarshalBinary)
+func (id *TypedID[T]) UnmarshalBinary(data []byte) error {
+	return (*UUID)(id).UnmarshalBinary(data)
+}
+
+// GobEncode implements the gob.GobEncoder interface (see UUID.GobEncode)
+func (id TypedID[T]) GobEncode() ([]byte, error) { return UUID(id).GobEncode() }
+
+// GobDecode implements the gob.GobDecoder interface (see UUID.GobDecode)
+func (id *TypedID[T]) GobDecode(data []byte) error {
+	return (*UUID)(id).GobDecode(data)
+}
+
+// Value implements the driver.Valuer interface (see UUID.Value)
+func (id TypedID[T]) Value() (driver.Value, error) { return UUID(id).Value() }
+
+// Scan implements the sql.Scanner interface, accepting the same formats as NullUUID.Scan.
+// An error is returned for NULL; use a *TypedID[T] destination to allow NULL.
+func (id *TypedID[T]) Scan(src any) error {
+	if src == nil {
+		return fmt.Errorf("uuid: cannot scan NULL into TypedID")
+	}
+	var n NullUUID
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*id = TypedID[T](n.UUID)
+	return nil
+}
@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestTypedID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	type user struct{}
+	type UserID = TypedID[user]
+	id := MustGen()
+	uid := UserID(id)
+	assert.Eq("UUID", uid.UUID(), id)
+	assert.Eq("String", uid.String(), id.String())
+	assert.Eq("Time", uid.Time(), id.Time())
+
+	type User struct {
+		ID UserID `json:"id"`
+	}
+	data, err := json.Marshal(User{uid})
+	assert.NoErr("json.Marshal", err)
+	assert.Eq("json.Marshal", string(data), `{"id":"`+id.String()+`"}`)
+	var u User
+	assert.NoErr("json.Unmarshal", json.Unmarshal(data, &u))
+	assert.Eq("json.Unmarshal", u.ID, uid)
+	legacy, _ := json.Marshal([16]byte(id))
+	u = User{}
+	assert.NoErr("json.Unmarshal legacy", json.Unmarshal([]byte(`{"id":`+string(legacy)+`}`), &u))
+	assert.Eq("json.Unmarshal legacy", u.ID, uid)
+
+	// gob data of UUIDs decodes into TypedIDs and vice versa
+	type Untyped struct{ ID UUID }
+	var buf bytes.Buffer
+	assert.NoErr("gob Encode UUID", gob.NewEncoder(&buf).Encode(Untyped{id}))
+	u = User{}
+	assert.NoErr("gob Decode TypedID", gob.NewDecoder(&buf).Decode(&u))
+	assert.Eq("gob Decode TypedID", u.ID, uid)
+	assert.NoErr("gob Encode TypedID", gob.NewEncoder(&buf).Encode(User{uid}))
+	var un Untyped
+	assert.NoErr("gob Decode UUID", gob.NewDecoder(&buf).Decode(&un))
+	assert.Eq("gob Decode UUID", un.ID, id)
+
+	b, err := uid.MarshalBinary()
+	assert.NoErr("MarshalBinary", err)
+	var uid2 UserID
+	assert.NoErr("UnmarshalBinary", uid2.UnmarshalBinary(b))
+	assert.Eq("UnmarshalBinary", uid2, uid)
+
+	v, err := uid.Value()
+	assert.NoErr("Value", err)
+	uid2 = UserID{}
+	assert.NoErr("Scan", uid2.Scan(v))
+	assert.Eq("Scan", uid2, uid)
+	assert.NoErr("Scan string", uid2.Scan(id.String()))
+	assert.Eq("Scan string", uid2, uid)
+	assert.Err("Scan NULL", "cannot scan NULL", uid2.Scan(nil))
+}
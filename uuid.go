@@ -2,6 +2,11 @@ package uuid
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
 	"time"
 )
 
@@ -56,11 +61,77 @@ const idEpochBase int64 = 1600000000
 
 // Gen generates a universally unique UUID suitable to be used for sorted identity.
 // An error is returned only in the case that the host system's random source fails.
+// Gen never falls back to pseudo-random bytes; see Generator.EntropyPolicy for that.
 func Gen() (UUID, error) {
+	id := genTime(time.Now(), idEpochBase)
+
+	// rest are random bytes
+	_, err := entropy.Read(id[8:16])
+	countGen(err)
+	return id, err
+}
+
+// GenStrict is like Gen but reads the random bytes of each UUID from crypto/rand directly,
+// rather than from the buffer Gen fills from crypto/rand, and returns an error if
+// crypto/rand fails. It never falls back to pseudo-random bytes, whatever the
+// EntropyPolicy of any Generator, and makes that requirement visible at the call site.
+// Reading crypto/rand for every UUID makes GenStrict slower than Gen.
+func GenStrict() (UUID, error) {
+	id := genTime(time.Now(), idEpochBase)
+	_, err := io.ReadFull(rand.Reader, id[8:16])
+	countGen(err)
+	return id, err
+}
+
+// GenFrom is like Gen but reads random bytes from r instead of the host system's random
+// source. This allows using other sources of entropy, like a hardware RNG or a DRBG.
+// An error is returned if r fails to provide 8 bytes, or in strict entropy mode (see
+// SetStrictEntropy) if r is not crypto/rand.Reader.
+func GenFrom(r io.Reader) (UUID, error) {
+	if err := checkStrictReader(r); err != nil {
+		return UUID{}, err
+	}
+	id := genTime(time.Now(), idEpochBase)
+	_, err := io.ReadFull(r, id[8:16])
+	countGen(err)
+	return id, err
+}
+
+// GenAt is like Gen but uses t as the timestamp instead of the current time, for example
+// when backfilling historical events. The random bytes are fresh, like those of Gen.
+// An error is returned if t is outside the range of timestamps representable by UUIDs
+// (2020-09-13 12:26:40 to 2156-10-20 18:54:55 UTC) or if the host system's random source
+// fails. To clamp such times instead, use Generator.GenAt with TimeRangeClamp.
+func GenAt(t time.Time) (UUID, error) {
+	if err := checkTime(t); err != nil {
+		return UUID{}, err
+	}
+	id := genTime(t, idEpochBase)
+	_, err := entropy.Read(id[8:16])
+	countGen(err)
+	return id, err
+}
+
+// GenOpaque generates a UUID made up of 16 random bytes, with no timestamp.
+// Use this for security-sensitive identifiers, like password reset tokens or invitation
+// codes, where revealing the time of creation is unacceptable.
+//
+// Opaque UUIDs don't sort by time and their Time() is meaningless. Random bytes are read
+// from crypto/rand directly rather than from the buffer used by Gen, so that bytes of
+// future tokens are not kept in memory.
+func GenOpaque() (UUID, error) {
+	var id UUID
+	_, err := io.ReadFull(rand.Reader, id[:])
+	countGen(err)
+	return id, err
+}
+
+// genTime returns a UUID with the timestamp of t relative to epoch in bytes 0-5 and
+// bits of the nanosecond part of t in bytes 6-7. Bytes 8-15 are left zero.
+func genTime(t time.Time, epoch int64) UUID {
 	var id UUID
 
-	t := time.Now()
-	sec := uint32(t.Unix() - idEpochBase)
+	sec := uint32(t.Unix() - epoch)
 	ns := uint64(t.Nanosecond())
 	ms := uint16(ns / uint64(time.Millisecond))
 
@@ -82,18 +153,21 @@ func Gen() (UUID, error) {
 	id[6] = byte(ns >> 24)
 	id[7] = byte(ns >> 16)
 
-	// rest are random bytes
-	_, err := rand.Read(id[8:16])
-	return id, err
+	return id
+}
+
+// checkTime returns an error if t is outside the range of timestamps representable by UUIDs
+func checkTime(t time.Time) error {
+	if sec := t.Unix() - idEpochBase; sec < 0 || sec > math.MaxUint32 {
+		return &OutOfRangeError{Time: t}
+	}
+	return nil
 }
 
 // MustGen calls Gen and panics if Gen fails
 func MustGen() UUID {
 	id, err := Gen()
 	if err != nil {
-		// Note: This is the only untested line of code.
-		// I don't know how to test failure of crypto/rand.Reader
-		// If you do know how, please add to uuid_test.go and submit a PR!
 		panic(err)
 	}
 	return id
@@ -111,10 +185,30 @@ func MustGen() UUID {
 // Up to 10 bytes is used from random.
 // If len(random) < 10, the remaining "random" bytes of UUID are zero.
 //
+// Times outside of the range representable by UUIDs (2020-09-13 12:26:40 to
+// 2156-10-20 18:54:55 UTC) silently wrap around; see NewFromTime for a checked variant.
+//
 func New(sec int64, nsec int, random []byte) UUID {
+	return newID(idEpochBase, sec, nsec, random)
+}
+
+// NewFromTime creates a new UUID with the timestamp t and random bytes, like
+// New(t.Unix(), t.Nanosecond(), random) but validating t. t is truncated to millisecond
+// precision. An error is returned if t is outside the range of timestamps representable by
+// UUIDs (2020-09-13 12:26:40 to 2156-10-20 18:54:55 UTC.) To clamp such times instead,
+// use Generator.NewFromTime with TimeRangeClamp.
+func NewFromTime(t time.Time, random []byte) (UUID, error) {
+	if err := checkTime(t); err != nil {
+		return UUID{}, err
+	}
+	return New(t.Unix(), t.Nanosecond(), random), nil
+}
+
+// newID implements New for any epoch
+func newID(epoch, sec int64, nsec int, random []byte) UUID {
 	var id UUID
 
-	s := uint32(sec - idEpochBase)
+	s := uint32(sec - epoch)
 	ms := uint16(nsec / int(time.Millisecond))
 
 	// second part
@@ -132,6 +226,54 @@ func New(sec int64, nsec int, random []byte) UUID {
 	return id
 }
 
+// NewChecked is like New but validates its arguments, for use with input from untrusted
+// callers. An error is returned if
+//
+//   - nsec is outside the range [0, 999999999] (*InvalidNsecError)
+//   - random is not exactly 10 bytes long (*RandomLengthError)
+//   - the time is outside the range representable by UUIDs, 2020-09-13 12:26:40 to
+//     2156-10-20 18:54:55 UTC (*OutOfRangeError)
+func NewChecked(sec int64, nsec int, random []byte) (UUID, error) {
+	if nsec < 0 || nsec > 999999999 {
+		return UUID{}, &InvalidNsecError{Nsec: nsec}
+	}
+	if len(random) != 10 {
+		return UUID{}, &RandomLengthError{Len: len(random)}
+	}
+	if err := checkTime(time.Unix(sec, int64(nsec))); err != nil {
+		return UUID{}, err
+	}
+	return New(sec, nsec, random), nil
+}
+
+// OutOfRangeError is returned when a time is outside the range of timestamps which can be
+// represented, e.g. by NewChecked and GenAt
+type OutOfRangeError struct {
+	Time time.Time
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("uuid: time %s out of range", e.Time.UTC().Format(time.RFC3339))
+}
+
+// InvalidNsecError is returned by NewChecked when nsec is outside the range [0, 999999999]
+type InvalidNsecError struct {
+	Nsec int
+}
+
+func (e *InvalidNsecError) Error() string {
+	return fmt.Sprintf("uuid: nanoseconds %d out of range [0, 999999999]", e.Nsec)
+}
+
+// RandomLengthError is returned by NewChecked when random is not exactly 10 bytes long
+type RandomLengthError struct {
+	Len int
+}
+
+func (e *RandomLengthError) Error() string {
+	return fmt.Sprintf("uuid: invalid random length %d (expected 10)", e.Len)
+}
+
 // FromBytes copies verbatim bytes into an UUID and returns that UUID.
 // verbatim must be at least 16 bytes long or this will panic.
 func FromBytes(verbatim []byte) UUID {
@@ -141,20 +283,68 @@ func FromBytes(verbatim []byte) UUID {
 }
 
 // FromString decodes a string representation of an UUID (i.e. from String())
+// The input is not validated; see Parse for a variant which is.
 func FromString(encoded string) UUID {
 	var id UUID
 	id.DecodeString([]byte(encoded))
 	return id
 }
 
+// Parse decodes a string representation of an UUID (i.e. from String()).
+// Unlike FromString, an error is returned if encoded is not a valid string representation.
+// See DecodeStringStrict for details.
+func Parse(encoded string) (UUID, error) {
+	var id UUID
+	err := id.DecodeStringStrict([]byte(encoded))
+	return id, err
+}
+
+// MustFromString is like Parse but panics if encoded is not a valid string representation.
+// It simplifies initialization of package-level variables and test fixtures.
+func MustFromString(encoded string) UUID {
+	id, err := Parse(encoded)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// TryFromString is like Parse but reports whether encoded is valid instead of returning an
+// error. It never allocates, which makes it suitable for hot paths where the reason for
+// invalid input doesn't matter.
+func TryFromString(encoded string) (UUID, bool) {
+	var id UUID
+	src := []byte(encoded)
+	if !validString(src) {
+		return id, false
+	}
+	id.DecodeString(src)
+	return id, true
+}
+
 // String returns a string representation of the UUID.
 // The returned string is sortable with the same order as the "raw" UUID bytes and is URL safe.
+// The encoding is done in a stack buffer so the only allocation is the returned string;
+// use EncodeString or AppendText to avoid that when writing into an existing buffer.
 func (id UUID) String() string {
 	var buf [StringMaxLen]byte
 	n := id.EncodeString(buf[:])
 	return string(buf[n:])
 }
 
+// StringPadded returns the same string representation as String, left-padded with "0" to
+// always be StringMaxLen (22) characters long. Unlike the strings returned by String, these
+// sort lexicographically in the same order as the UUIDs, and suit fixed-width layouts.
+// Parse, FromString and DecodeString accept both padded and unpadded strings.
+func (id UUID) StringPadded() string {
+	buf := [StringMaxLen]byte{
+		'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0',
+		'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0',
+	}
+	id.EncodeString(buf[:])
+	return string(buf[:])
+}
+
 // Bytes returns the IDs natural 16 byte long value.
 // The returned slice's bytes must not be modified.
 func (id UUID) Bytes() []byte {
@@ -163,8 +353,76 @@ func (id UUID) Bytes() []byte {
 
 // Time returns the time portion of the UUID
 func (id UUID) Time() time.Time {
+	return id.timeWithEpoch(idEpochBase)
+}
+
+func (id UUID) timeWithEpoch(epoch int64) time.Time {
+	sec, ms := id.Timestamp()
+	return time.Unix(int64(sec)+epoch, int64(ms)*int64(time.Millisecond))
+}
+
+// WithTime returns a copy of id with its time portion (bytes 0-5) replaced by t, truncated
+// to millisecond precision. The random bytes are kept as-is.
+func (id UUID) WithTime(t time.Time) UUID {
+	id.SetTime(t)
+	return id
+}
+
+// SetTime replaces the time portion (bytes 0-5) of id with t, truncated to millisecond
+// precision. The random bytes are kept as-is.
+func (id *UUID) SetTime(t time.Time) {
+	tid := New(t.Unix(), t.Nanosecond(), nil)
+	copy(id[:6], tid[:6])
+}
+
+// StripTime returns a copy of id with its time portion (bytes 0-5) set to zero, keeping the
+// random bytes. This is useful for exporting IDs without revealing when they were created.
+//
+// Note that stripped UUIDs no longer sort by time and only their 10 random bytes make them
+// unique. Time() of a stripped UUID returns the epoch, 2020-09-13 12:26:40 UTC.
+func (id UUID) StripTime() UUID {
+	copy(id[:6], Min[:6])
+	return id
+}
+
+// StripTimes calls StripTime on every UUID in ids, modifying ids in place
+func StripTimes(ids []UUID) {
+	for i := range ids {
+		ids[i] = ids[i].StripTime()
+	}
+}
+
+// UnixMilli returns the time portion of the UUID as a Unix time in milliseconds.
+// This is equivalent to id.Time().UnixMilli() but cheaper.
+func (id UUID) UnixMilli() int64 {
 	sec, ms := id.Timestamp()
-	return time.Unix(int64(sec)+idEpochBase, int64(ms)*int64(time.Millisecond))
+	return (int64(sec)+idEpochBase)*1000 + int64(ms)
+}
+
+// UnixMicro returns the time portion of the UUID as a Unix time in microseconds.
+// Since UUIDs have millisecond precision, the result is always a multiple of 1000.
+func (id UUID) UnixMicro() int64 {
+	return id.UnixMilli() * 1000
+}
+
+// Age returns the time elapsed since the time of the UUID, i.e. time.Since(id.Time())
+func (id UUID) Age() time.Duration {
+	return time.Since(id.Time())
+}
+
+// CreatedBefore reports whether the time of the UUID is before t, comparing at millisecond
+// precision: t is truncated to milliseconds like the timestamps of UUIDs are, so a UUID
+// created in the same millisecond as t is neither CreatedBefore nor CreatedAfter t.
+// This matches range scans: the UUIDs created before t are those less than
+// TimeRange(t, t).Start.
+func (id UUID) CreatedBefore(t time.Time) bool {
+	return id.UnixMilli() < unixMilli(t)
+}
+
+// CreatedAfter reports whether the time of the UUID is after t, comparing at millisecond
+// precision (see CreatedBefore.)
+func (id UUID) CreatedAfter(t time.Time) bool {
+	return id.UnixMilli() > unixMilli(t)
 }
 
 // Timestamp returns the timestamp portion of the UUID
@@ -174,8 +432,47 @@ func (id UUID) Timestamp() (sec uint32, millisec uint16) {
 	return
 }
 
+// Random returns the random portion of the UUID (bytes 6-15), the counterpart to Timestamp
+func (id UUID) Random() (random [10]byte) {
+	copy(random[:], id[6:])
+	return
+}
+
+// Truncate returns a copy of id with its timestamp rounded down to a multiple of d,
+// keeping the random bytes. This reduces the timing information revealed by an ID while
+// keeping it coarsely sortable by time.
+//
+// Rounding is relative to the Unix epoch, i.e. with d = 24h the time becomes midnight UTC.
+// Only the millisecond part of d is used; d smaller than one millisecond means no rounding.
+// Times which would be rounded to before the beginning of the UUID epoch are clamped to it.
+func (id UUID) Truncate(d time.Duration) UUID {
+	t := id.UnixMilli()
+	if step := int64(d / time.Millisecond); step > 1 {
+		t -= t % step
+		if t < idEpochBase*1000 {
+			t = idEpochBase * 1000
+		}
+	}
+	return id.WithTime(time.Unix(t/1000, t%1000*int64(time.Millisecond)))
+}
+
+// Bucket returns the smallest UUID within the time bucket of duration d that id falls in.
+// The returned UUID has the same timestamp as id rounded down to a multiple of d, and all
+// random bytes set to zero. This makes it useful both as a key when aggregating IDs by time
+// and as the inclusive lower bound of a range scan over a bucket.
+//
+// Buckets are aligned to the Unix epoch, i.e. 24 hour buckets start at midnight UTC.
+// Only the millisecond part of d is used; d smaller than one millisecond means no rounding.
+// Buckets which would start before the beginning of the UUID epoch are clamped to it.
+func (id UUID) Bucket(d time.Duration) UUID {
+	t := id.Truncate(d)
+	var b UUID
+	copy(b[:6], t[:6])
+	return b
+}
+
 /*
-EncodeString and DecodeString have been adapted from the ksuid project,
+EncodeString and DecodeString were originally adapted from the ksuid project,
 licensed as follows:
 
 MIT License
@@ -203,64 +500,54 @@ SOFTWARE.
 
 const base62Characters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 
+// maxString is the string representation of Max
+const maxString = "7n42DGM5Tflk9n8mt7Fhc7"
+
 // EncodeString writes the receiver to dst which must be at least StringMaxLen (22) bytes.
 // Returns the start offset (this function starts writing at the end of dst.)
 func (id UUID) EncodeString(dst []byte) int {
-	const srcBase = 0x100000000
-	const dstBase = 62
-
-	parts := [4]uint32{
-		uint32(id[0])<<24 | uint32(id[1])<<16 | uint32(id[2])<<8 | uint32(id[3]),
-		uint32(id[4])<<24 | uint32(id[5])<<16 | uint32(id[6])<<8 | uint32(id[7]),
-		uint32(id[8])<<24 | uint32(id[9])<<16 | uint32(id[10])<<8 | uint32(id[11]),
-		uint32(id[12])<<24 | uint32(id[13])<<16 | uint32(id[14])<<8 | uint32(id[15]),
-	}
+	// 62^10 is the largest power of 62 that fits in 64 bits. Dividing the 128-bit value
+	// by it yields 10 digits at a time which are then extracted with 64-bit arithmetic.
+	const chunkDigits = 10
+	const chunkBase = 839299365868340224 // 62^10
 
-	n := len(dst)
-	bp := parts[:]
-	bq := [4]uint32{}
+	_ = dst[StringMaxLen-1] // bounds check
 	dst[0] = '0'
-
-	for len(bp) != 0 {
-		quotient := bq[:0]
-		remainder := uint64(0)
-
-		for _, c := range bp {
-			value := uint64(c) + uint64(remainder)*srcBase
-			digit := value / dstBase
-			remainder = value % dstBase
-
-			if len(quotient) != 0 || digit != 0 {
-				quotient = append(quotient, uint32(digit))
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	n := len(dst)
+	for {
+		var r uint64
+		hi, r = hi/chunkBase, hi%chunkBase
+		lo, r = bits.Div64(r, lo, chunkBase)
+		if hi == 0 && lo == 0 {
+			// most significant chunk; no leading zeros
+			for {
+				n--
+				dst[n] = base62Characters[r%62]
+				r /= 62
+				if r == 0 {
+					return n
+				}
 			}
 		}
-
-		// Writes at the end of the destination buffer because we computed the
-		// lowest bits first.
-		n--
-		dst[n] = base62Characters[remainder]
-		bp = quotient
+		for i := 0; i < chunkDigits; i++ {
+			n--
+			dst[n] = base62Characters[r%62]
+			r /= 62
+		}
 	}
-
-	return n
 }
 
 // DecodeString sets the receiving UUID to the decoded value of src, which is expected to be a
 // string previously encoded using EncodeString (base62 0-9A-Za-z)
 func (id *UUID) DecodeString(src []byte) {
-	const srcBase = 62
-	const dstBase = 0x100000000
-
-	parts := [StringMaxLen]byte{}
+	// offsets into base62Characters
+	const offsetUppercase = 10
+	const offsetLowercase = 36
 
-	partsIndex := 21
-	for i := len(src); i > 0; {
-		// offsets into base62Characters
-		const offsetUppercase = 10
-		const offsetLowercase = 36
-
-		i--
-		b := src[i]
+	var hi, lo uint64
+	for _, b := range src {
 		switch {
 		case b >= '0' && b <= '9':
 			b -= '0'
@@ -269,36 +556,72 @@ func (id *UUID) DecodeString(src []byte) {
 		default:
 			b = offsetLowercase + (b - 'a')
 		}
-		parts[partsIndex] = b
-		partsIndex--
+		// (hi, lo) = (hi, lo) * 62 + b
+		carry, lo1 := bits.Mul64(lo, 62)
+		lo1, c := bits.Add64(lo1, uint64(b), 0)
+		hi = hi*62 + carry + c
+		lo = lo1
 	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+}
 
-	n := len(id)
-	bp := parts[:]
-	bq := make([]byte, 0, len(src))
+// InvalidCharError is returned by DecodeStringStrict when its input contains a byte
+// which is not part of the base62 alphabet (0-9A-Za-z)
+type InvalidCharError struct {
+	Char   byte // the offending byte
+	Offset int  // offset of Char in the input
+}
 
-	for len(bp) > 0 {
-		quotient := bq[:0]
-		remainder := uint64(0)
+func (e *InvalidCharError) Error() string {
+	if e.Char >= 0x80 { // part of a multi-byte UTF-8 sequence (or not UTF-8 at all)
+		return fmt.Sprintf("uuid: invalid byte %#02x at offset %d", e.Char, e.Offset)
+	}
+	return fmt.Sprintf("uuid: invalid character %q at offset %d", e.Char, e.Offset)
+}
 
-		for _, c := range bp {
-			value := uint64(c) + uint64(remainder)*srcBase
-			digit := value / dstBase
-			remainder = value % dstBase
+// DecodeStringStrict is like DecodeString but validates src.
+// If src contains a character outside of the base62 alphabet an *InvalidCharError is
+// returned, identifying the first offending character.
+// An error is also returned if src is empty, longer than StringMaxLen or encodes a value
+// larger than Max (which can't be represented in 128 bits.)
+// The receiver is only modified when src is valid.
+func (id *UUID) DecodeStringStrict(src []byte) error {
+	if !validString(src) {
+		return invalidStringError(src)
+	}
+	id.DecodeString(src)
+	return nil
+}
 
-			if len(quotient) != 0 || digit != 0 {
-				quotient = append(quotient, byte(digit))
-			}
+// validString reports whether src is a valid string representation of a UUID
+func validString(src []byte) bool {
+	if len(src) == 0 || len(src) > StringMaxLen {
+		return false
+	}
+	for _, c := range src {
+		if !isBase62(c) {
+			return false
 		}
+	}
+	// base62Characters are in ASCII order, so for strings of equal length the byte-wise
+	// order is the same as numeric order.
+	return len(src) < StringMaxLen || string(src) <= maxString
+}
 
-		id[n-4] = byte(remainder >> 24)
-		id[n-3] = byte(remainder >> 16)
-		id[n-2] = byte(remainder >> 8)
-		id[n-1] = byte(remainder)
-		n -= 4
-		bp = quotient
+// invalidStringError returns an error describing why src failed validString
+func invalidStringError(src []byte) error {
+	if len(src) == 0 || len(src) > StringMaxLen {
+		return fmt.Errorf("uuid: invalid string length %d", len(src))
+	}
+	for i, c := range src {
+		if !isBase62(c) {
+			return &InvalidCharError{Char: c, Offset: i}
+		}
 	}
+	return fmt.Errorf("uuid: %q overflows 128 bits", src)
+}
 
-	var zero [16]byte
-	copy(id[:n], zero[:])
+func isBase62(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
 }
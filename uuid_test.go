@@ -1,6 +1,12 @@
 package uuid
 
 import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	mathrand "math/rand"
 	"testing"
 	"time"
 
@@ -50,6 +56,35 @@ func TestUUID(t *testing.T) {
 		idt.Time().Nanosecond()/int(time.Millisecond),
 		tm.Nanosecond()/int(time.Millisecond))
 
+	assert.Eq("UnixMilli()", idt.UnixMilli(), tm.UnixMilli())
+	assert.Eq("UnixMicro()", idt.UnixMicro(), tm.UnixMicro())
+	assert.Eq("Min UnixMilli()", Min.UnixMilli(), int64(1600000000000))
+
+	// WithTime and SetTime replace the time portion only
+	tm2 := time.Date(2021, 2, 3, 4, 5, 6, 789*int(time.Millisecond), time.UTC)
+	id4 := id1.WithTime(tm2)
+	assert.Eq("WithTime time", id4.Time().UTC(), tm2)
+	assert.Eq("WithTime keeps random bytes", id4[6:], id1[6:])
+	assert.Ok("WithTime does not modify receiver", id1.Time() != id4.Time())
+	id4.SetTime(tm)
+	assert.Eq("SetTime time", id4.UnixMilli(), tm.UnixMilli())
+	assert.Eq("SetTime keeps random bytes", id4[6:], id1[6:])
+
+	// StripTime zeroes the time portion
+	stripped := id1.StripTime()
+	assert.Eq("StripTime time bytes", stripped[:6], make([]byte, 6))
+	assert.Eq("StripTime keeps random bytes", stripped[6:], id1[6:])
+	assert.Eq("StripTime Time()", stripped.Time(), Min.Time())
+	ids := []UUID{id1, id2}
+	StripTimes(ids)
+	assert.Eq("StripTimes [0]", ids[0], id1.StripTime())
+	assert.Eq("StripTimes [1]", ids[1], id2.StripTime())
+
+	// Random returns bytes 6-15
+	random := id1.Random()
+	assert.Eq("Random()", random[:], id1[6:])
+	assert.Eq("New(Time, Random) == id", New(id1.Time().Unix(), id1.Time().Nanosecond(), random[:]), id1)
+
 	// raw byte representation
 	bytes := id1.Bytes()
 	id1b := FromBytes(bytes)
@@ -64,3 +99,483 @@ func TestUUID(t *testing.T) {
 	// 	t.Logf("% x  %q  %s", id[:], id, id.Time().UTC())
 	// }
 }
+
+func TestBucket(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 2020-10-20 16:45:45.713 UTC
+	id := New(1603212345, 713*int(time.Millisecond), []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	hour := id.Bucket(time.Hour)
+	assert.Eq("hour bucket time", hour.Time().UTC(), time.Date(2020, 10, 20, 16, 0, 0, 0, time.UTC))
+	assert.Eq("hour bucket random bytes are zero", hour[6:], make([]byte, 10))
+
+	day := id.Bucket(24 * time.Hour)
+	assert.Eq("day bucket time", day.Time().UTC(), time.Date(2020, 10, 20, 0, 0, 0, 0, time.UTC))
+
+	// IDs within the same bucket share the bucket key
+	id2 := New(1603212345+60, 0, []byte{9, 9, 9})
+	assert.Eq("same hour bucket", id2.Bucket(time.Hour), hour)
+	assert.Ok("bucket sorts before ids in bucket", string(hour[:]) <= string(id[:]))
+
+	// sub-millisecond durations only clear the random bytes
+	assert.Eq("1ns bucket", id.Bucket(1), New(1603212345, 713*int(time.Millisecond), nil))
+
+	// buckets starting before the epoch are clamped
+	assert.Eq("clamped bucket", Min.Bucket(24*time.Hour), Min)
+}
+
+func TestDecodeStringStrict(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	id1 := MustGen()
+	id, err := Parse(id1.String())
+	assert.NoErr("Parse", err)
+	assert.Eq("Parse(String())", id, id1)
+	id, err = Parse(Max.String())
+	assert.NoErr("Parse Max", err)
+	assert.Eq("Parse Max", id, Max)
+
+	_, err = Parse("abc-def")
+	assert.Err("Parse abc-def", `invalid character '-' at offset 3`, err)
+	cerr, ok := err.(*InvalidCharError)
+	assert.Ok("error is *InvalidCharError", ok)
+	assert.Eq("InvalidCharError.Char", cerr.Char, byte('-'))
+	assert.Eq("InvalidCharError.Offset", cerr.Offset, 3)
+
+	_, err = Parse("abå")
+	assert.Err("Parse unicode", `invalid byte 0xc3 at offset 2`, err)
+	_, err = Parse("")
+	assert.Err("Parse empty", "invalid string length 0", err)
+
+	// overflow and length
+	_, err = Parse("zzzzzzzzzzzzzzzzzzzzzz")
+	assert.Err("Parse overflow", "overflows 128 bits", err)
+	_, err = Parse("7n42DGM5Tflk9n8mt7Fhc8") // Max+1
+	assert.Err("Parse Max+1", "overflows 128 bits", err)
+	id, err = Parse("0000000000000000000009")
+	assert.NoErr("Parse zero-padded", err)
+	assert.Eq("Parse zero-padded", id, UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 9})
+	_, err = Parse("00000000000000000000009")
+	assert.Err("Parse too long", "invalid string length 23", err)
+
+	assert.Eq("MustFromString", MustFromString(id1.String()), id1)
+	assert.Panic("invalid character .-. at offset 3", func() { MustFromString("abc-def") })
+
+	id, ok = TryFromString(id1.String())
+	assert.Ok("TryFromString", ok && id == id1)
+	for _, s := range []string{"", "abc-def", "zzzzzzzzzzzzzzzzzzzzzz", "00000000000000000000009"} {
+		id, ok = TryFromString(s)
+		assert.Ok("TryFromString(%q)", !ok && id == Min, s)
+	}
+	s := id1.String()
+	allocs := testing.AllocsPerRun(100, func() { TryFromString(s); TryFromString("abc-def") })
+	assert.Eq("TryFromString allocations", allocs, 0.0)
+
+	// receiver is untouched for invalid input
+	id = id1
+	assert.Err("DecodeStringStrict invalid", "invalid character",
+		id.DecodeStringStrict([]byte("A A")))
+	assert.Eq("receiver unmodified", id, id1)
+}
+
+func TestGenFrom(t *testing.T) {
+	skipInStrictBuild(t)
+	assert := testutil.NewAssert(t)
+
+	r := bytes.NewReader([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	id, err := GenFrom(r)
+	assert.NoErr("GenFrom", err)
+	assert.Eq("random bytes from reader", id[8:], []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.Ok("time", time.Since(id.Time()) < time.Minute)
+
+	_, err = GenFrom(r) // only 3 bytes left
+	assert.Err("GenFrom short read", "unexpected EOF", err)
+
+	g := Generator{Rand: bytes.NewReader([]byte{8, 7, 6, 5, 4, 3, 2, 1})}
+	id, err = g.Gen()
+	assert.NoErr("Generator.Gen with Rand", err)
+	assert.Eq("Generator random bytes from reader", id[8:], []byte{8, 7, 6, 5, 4, 3, 2, 1})
+	_, err = g.Gen()
+	assert.Err("Generator.Gen with exhausted Rand", "EOF", err)
+}
+
+func TestGenConcurrent(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	const goroutines = 8
+	const count = 2000 // more than fits in one fill of the entropy pool
+	results := make(chan []UUID)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			ids := make([]UUID, count)
+			for i := range ids {
+				ids[i] = MustGen()
+			}
+			results <- ids
+		}()
+	}
+	seen := make(map[UUID]bool, goroutines*count)
+	for i := 0; i < goroutines; i++ {
+		for _, id := range <-results {
+			assert.Ok("unique", !seen[id])
+			seen[id] = true
+		}
+	}
+}
+
+func TestEntropyShards(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	assert.Eq("shards for 1 P", len(newEntropyShards(1).pools), 1)
+	assert.Eq("shards for 3 Ps", len(newEntropyShards(3).pools), 4)
+	assert.Eq("shards for 8 Ps", len(newEntropyShards(8).pools), 8)
+	assert.Eq("shards for 1000 Ps", len(newEntropyShards(1000).pools), maxEntropyShards)
+
+	// concurrent readers are spread over the pools and never get the same bytes
+	s := newEntropyShards(4)
+	const goroutines = 8
+	const count = 1000
+	results := make(chan [][8]byte)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			chunks := make([][8]byte, count)
+			for i := range chunks {
+				_, err := s.Read(chunks[i][:])
+				assert.NoErr("Read", err)
+			}
+			results <- chunks
+		}()
+	}
+	seen := make(map[[8]byte]bool, goroutines*count)
+	for i := 0; i < goroutines; i++ {
+		for _, chunk := range <-results {
+			assert.Ok("unique", !seen[chunk])
+			seen[chunk] = true
+		}
+	}
+	for i := range s.pools {
+		p := &s.pools[i]
+		if p.buf != nil {
+			assert.Ok("used bytes cleared", bytes.Count(p.buf[:p.off], []byte{0}) == p.off)
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(b []byte) (int, error) {
+	return 0, errors.New("entropy unavailable")
+}
+
+// replaceEntropySource makes the entropy pools read from r, emptying their buffers, and
+// returns a function which restores crypto/rand.Reader
+func replaceEntropySource(r io.Reader) (restore func()) {
+	lockAll := func(f func()) {
+		for i := range entropy.pools {
+			entropy.pools[i].mu.Lock()
+		}
+		f()
+		for i := range entropy.pools {
+			entropy.pools[i].mu.Unlock()
+		}
+	}
+	reader := rand.Reader
+	lockAll(func() {
+		rand.Reader = r
+		for i := range entropy.pools {
+			entropy.pools[i].off = entropyPoolSize
+		}
+	})
+	return func() { lockAll(func() { rand.Reader = reader }) }
+}
+
+func TestGenEntropyFailure(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// replace crypto/rand.Reader and empty the buffers of random bytes
+	defer replaceEntropySource(failingReader{})()
+
+	_, err := Gen()
+	assert.Err("Gen", "entropy unavailable", err)
+	_, err = GenStrict()
+	assert.Err("GenStrict", "entropy unavailable", err)
+	// a Generator falling back to pseudo-random bytes doesn't affect GenStrict
+	_, err = (&Generator{EntropyPolicy: EntropyFallback}).Gen()
+	assert.Eq("Generator with EntropyFallback fails in strict builds only", err != nil,
+		strictEntropyBuild)
+	fallbacks := ReadStats().EntropyFallbacks
+	_, err = GenStrict()
+	assert.Err("GenStrict with a fallback Generator", "entropy unavailable", err)
+	assert.Eq("GenStrict doesn't fall back", ReadStats().EntropyFallbacks, fallbacks)
+	assert.Panic("entropy unavailable", func() { MustGen() })
+	var g Generator
+	_, err = g.Gen()
+	assert.Err("Generator.Gen", "entropy unavailable", err)
+}
+
+func TestGenStrictBypassesBuffer(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// fill every buffer, then make crypto/rand fail
+	for i := range entropy.pools {
+		p := &entropy.pools[i]
+		p.mu.Lock()
+		if p.buf == nil {
+			p.buf = new([entropyPoolSize]byte)
+		}
+		_, err := rand.Read(p.buf[:])
+		assert.NoErr("rand.Read", err)
+		p.off = 0
+		p.mu.Unlock()
+	}
+	reader := rand.Reader
+	rand.Reader = failingReader{}
+	defer func() { rand.Reader = reader }()
+
+	_, err := Gen()
+	assert.NoErr("Gen from buffer", err)
+	_, err = GenStrict()
+	assert.Err("GenStrict", "entropy unavailable", err)
+}
+
+func BenchmarkGen(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MustGen()
+	}
+}
+
+func BenchmarkGenParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			MustGen()
+		}
+	})
+}
+
+var benchSink string
+
+func BenchmarkString(b *testing.B) {
+	id := MustGen()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchSink = id.String()
+	}
+}
+
+func BenchmarkEncodeString(b *testing.B) {
+	id := MustGen()
+	var buf [StringMaxLen]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		id.EncodeString(buf[:])
+	}
+}
+
+func BenchmarkDecodeString(b *testing.B) {
+	src := []byte(MustGen().String())
+	var id UUID
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		id.DecodeString(src)
+	}
+}
+
+func TestEncodeDecodeString(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	// compare with math/big as the reference implementation
+	r := mathrand.New(mathrand.NewSource(1))
+	var buf [StringMaxLen]byte
+	for i := 0; i < 1000; i++ {
+		var id UUID
+		r.Read(id[:])
+		id[0] >>= uint(i % 8) // vary the length of the string
+		s := string(buf[id.EncodeString(buf[:]):])
+		n := new(big.Int).SetBytes(id[:])
+		expect := make([]byte, 0, StringMaxLen)
+		for q, m := new(big.Int).Set(n), new(big.Int); ; {
+			q.DivMod(q, big.NewInt(62), m)
+			expect = append([]byte{base62Characters[m.Int64()]}, expect...)
+			if q.Sign() == 0 {
+				break
+			}
+		}
+		assert.Eq("EncodeString", s, string(expect))
+		var id2 UUID
+		id2.DecodeString([]byte(s))
+		assert.Eq("DecodeString", id2, id)
+	}
+}
+
+func TestStringAllocs(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := MustGen()
+	var buf [StringMaxLen]byte
+	// the only allocation of String is the returned string itself
+	assert.Eq("String", testing.AllocsPerRun(100, func() { benchSink = id.String() }), 1.0)
+	assert.Eq("StringPadded", testing.AllocsPerRun(100, func() { benchSink = id.StringPadded() }), 1.0)
+	assert.Eq("EncodeString", testing.AllocsPerRun(100, func() { id.EncodeString(buf[:]) }), 0.0)
+	src := []byte(id.String())
+	assert.Eq("DecodeString", testing.AllocsPerRun(100, func() { id.DecodeString(src) }), 0.0)
+}
+
+func TestTruncate(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 2020-10-20 16:45:45.713 UTC
+	random := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	id := New(1603212345, 713*int(time.Millisecond), random)
+
+	hour := id.Truncate(time.Hour)
+	assert.Eq("Truncate hour", hour.Time().UTC(), time.Date(2020, 10, 20, 16, 0, 0, 0, time.UTC))
+	assert.Eq("Truncate keeps random bytes", hour[6:], random)
+	assert.Eq("Truncate second", id.Truncate(time.Second).Time().UTC(),
+		time.Date(2020, 10, 20, 16, 45, 45, 0, time.UTC))
+	assert.Eq("Truncate 1ns", id.Truncate(1), id)
+	assert.Eq("Truncate clamped", Max.StripTime().Truncate(24*time.Hour), Max.StripTime())
+
+	// order is kept for IDs in different buckets
+	later := New(1603212345+3600, 0, []byte{0})
+	assert.Ok("order", string(hour[:]) < string(later.Truncate(time.Hour).Bytes()))
+}
+
+func TestStringPadded(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	assert.Eq("Min", Min.StringPadded(), "0000000000000000000000")
+	assert.Eq("Max", Max.StringPadded(), Max.String())
+	smallId := UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xA}
+	assert.Eq("smallId", smallId.StringPadded(), "000000000000000000000A")
+
+	for _, id := range []UUID{Min, Max, smallId, MustGen()} {
+		s := id.StringPadded()
+		assert.Eq("length", len(s), StringMaxLen)
+		id2, err := Parse(s)
+		assert.NoErr("Parse(StringPadded())", err)
+		assert.Eq("Parse(StringPadded())", id2, id)
+		assert.Eq("FromString(StringPadded())", FromString(s), id)
+	}
+
+	// padded strings sort like the UUIDs
+	a := UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 61} // "z"
+	b := UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 62} // "10"
+	assert.Ok("unpadded strings don't sort", a.String() > b.String())
+	assert.Ok("padded strings sort", a.StringPadded() < b.StringPadded())
+}
+
+func TestGenOpaque(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	seen := make(map[UUID]bool)
+	var or UUID
+	for i := 0; i < 100; i++ {
+		id, err := GenOpaque()
+		assert.NoErr("GenOpaque", err)
+		assert.Ok("unique", !seen[id])
+		seen[id] = true
+		for j := range id {
+			or[j] |= id[j]
+		}
+	}
+	// all bytes are random, including the ones normally holding the timestamp
+	assert.Eq("all bytes random", or, Max)
+}
+
+func TestGenAt(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 891234567, time.UTC)
+	id1, err := GenAt(tm)
+	assert.NoErr("GenAt", err)
+	id2, _ := GenAt(tm)
+	assert.Eq("GenAt time", id1.Time().UTC(), tm.Truncate(time.Millisecond))
+	assert.Eq("same timestamp", id1[:6], id2[:6])
+	assert.Ok("fresh random bytes", string(id1[8:]) != string(id2[8:]))
+
+	_, err = GenAt(time.Unix(idEpochBase-1, 0))
+	assert.Err("GenAt before epoch", "out of range", err)
+	_, err = GenAt(time.Date(2157, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Err("GenAt after epoch", "out of range", err)
+}
+
+func TestNewFromTime(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 891234567, time.UTC)
+	random := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	id, err := NewFromTime(tm, random)
+	assert.NoErr("NewFromTime", err)
+	assert.Eq("NewFromTime", id, New(tm.Unix(), tm.Nanosecond(), random))
+
+	id, err = NewFromTime(time.Unix(idEpochBase, 0), nil)
+	assert.NoErr("NewFromTime start of epoch", err)
+	assert.Eq("NewFromTime start of epoch", id, Min)
+	id, err = NewFromTime(time.Unix(idEpochBase+0xffffffff, 999999999), nil)
+	assert.NoErr("NewFromTime end of epoch", err)
+	assert.Eq("NewFromTime end of epoch", id.Time(), time.Unix(idEpochBase+0xffffffff, 999000000))
+
+	_, err = NewFromTime(time.Unix(idEpochBase-1, 999999999), nil)
+	assert.Err("NewFromTime before epoch", "time 2020-09-13T12:26:39Z out of range", err)
+	_, err = NewFromTime(time.Unix(idEpochBase+0x100000000, 0), nil)
+	assert.Err("NewFromTime after epoch", "out of range", err)
+}
+
+func TestNewChecked(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 891234567, time.UTC)
+	random := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	id, err := NewChecked(tm.Unix(), tm.Nanosecond(), random)
+	assert.NoErr("NewChecked", err)
+	assert.Eq("NewChecked", id, New(tm.Unix(), tm.Nanosecond(), random))
+
+	var nerr *InvalidNsecError
+	_, err = NewChecked(tm.Unix(), 1e9, random)
+	assert.Ok("InvalidNsecError", errors.As(err, &nerr) && nerr.Nsec == 1e9)
+	_, err = NewChecked(tm.Unix(), -1, random)
+	assert.Err("InvalidNsecError", "uuid: nanoseconds -1 out of range [0, 999999999]", err)
+
+	var lerr *RandomLengthError
+	_, err = NewChecked(tm.Unix(), 0, random[:9])
+	assert.Ok("RandomLengthError", errors.As(err, &lerr) && lerr.Len == 9)
+	_, err = NewChecked(tm.Unix(), 0, append(random, 11))
+	assert.Err("RandomLengthError", "uuid: invalid random length 11 (expected 10)", err)
+
+	var rerr *OutOfRangeError
+	_, err = NewChecked(idEpochBase-1, 0, random)
+	assert.Ok("OutOfRangeError", errors.As(err, &rerr) && rerr.Time.Unix() == idEpochBase-1)
+	_, err = NewChecked(idEpochBase+0x100000000, 0, random)
+	assert.Err("OutOfRangeError", "out of range", err)
+
+	// GenAt and NewFromTime return the same error type
+	_, err = GenAt(time.Unix(idEpochBase-1, 0))
+	assert.Ok("GenAt OutOfRangeError", errors.As(err, &rerr))
+	_, err = (&Generator{}).GenAt(time.Unix(idEpochBase-1, 0))
+	assert.Ok("Generator.GenAt OutOfRangeError", errors.As(err, &rerr))
+}
+
+func TestAge(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := New(time.Now().Add(-time.Hour).Unix(), 0, nil)
+	age := id.Age()
+	assert.Ok("Age %v", age >= time.Hour && age < time.Hour+2*time.Second, age)
+	assert.Ok("Age of new UUID", MustGen().Age() < time.Second)
+}
+
+func TestCreatedBeforeAfter(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	// 2020-10-20 16:45:45.713 UTC
+	id := New(1603212345, 713*int(time.Millisecond), []byte{0xff, 0xff})
+	tm := time.Unix(1603212345, 713*int64(time.Millisecond))
+
+	assert.Ok("same ms", !id.CreatedBefore(tm) && !id.CreatedAfter(tm))
+	assert.Ok("same ms, later ns", !id.CreatedBefore(tm.Add(999*time.Microsecond)))
+	assert.Ok("same ms, later ns", !id.CreatedAfter(tm.Add(999*time.Microsecond)))
+	assert.Ok("before next ms", id.CreatedBefore(tm.Add(time.Millisecond)))
+	assert.Ok("after previous ms", id.CreatedAfter(tm.Add(-time.Nanosecond)))
+	assert.Ok("not before previous ms", !id.CreatedBefore(tm.Add(-time.Nanosecond)))
+	assert.Ok("after 1970", id.CreatedAfter(time.Unix(-1, 0)))
+
+	// consistent with range bounds
+	for _, d := range []time.Duration{-time.Millisecond, -time.Nanosecond, 0, time.Nanosecond, time.Millisecond} {
+		bound := TimeRange(tm.Add(d), tm.Add(d)).Start
+		assert.Eq("CreatedBefore matches TimeRange", id.CreatedBefore(tm.Add(d)),
+			bytes.Compare(id[:], bound[:]) < 0)
+	}
+}
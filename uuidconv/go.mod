@@ -0,0 +1,17 @@
+module github.com/rsms/go-uuid/uuidconv
+
+go 1.25.0
+
+require (
+	github.com/gofrs/uuid/v5 v5.5.1
+	github.com/google/uuid v1.6.0
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+)
+
+require (
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
@@ -0,0 +1,82 @@
+// Package uuidconv converts between github.com/rsms/go-uuid UUIDs and the UUID types of
+// github.com/google/uuid and github.com/gofrs/uuid.
+//
+// Two kinds of conversions are provided:
+//
+// Verbatim conversions (ToGoogle, FromGoogle, ToGofrs, FromGofrs) copy the 16 bytes as-is.
+// These are lossless in both directions, but a UUID converted this way is not a valid RFC
+// 9562 UUID and has no meaningful timestamp in the other representation, and vice versa.
+//
+// Timestamp-mapping conversions (ToGoogleV7, FromGoogleV7, ToGofrsV7, FromGofrsV7) map
+// between this package's layout and RFC 9562 version 7 UUIDs, which also start with a
+// millisecond timestamp. The timestamp is preserved and so is the sort order of UUIDs
+// created in different milliseconds, but 6 bits are lost to the version and variant fields
+// of the RFC layout, thus a round trip does not produce the original UUID.
+package uuidconv
+
+import (
+	"fmt"
+	"time"
+
+	gofrs "github.com/gofrs/uuid/v5"
+	google "github.com/google/uuid"
+	"github.com/rsms/go-uuid"
+)
+
+// ToGoogle returns the bytes of id verbatim as a google/uuid UUID
+func ToGoogle(id uuid.UUID) google.UUID { return google.UUID(id) }
+
+// FromGoogle returns the bytes of u verbatim as a UUID
+func FromGoogle(u google.UUID) uuid.UUID { return uuid.UUID(u) }
+
+// ToGofrs returns the bytes of id verbatim as a gofrs/uuid UUID
+func ToGofrs(id uuid.UUID) gofrs.UUID { return gofrs.UUID(id) }
+
+// FromGofrs returns the bytes of u verbatim as a UUID
+func FromGofrs(u gofrs.UUID) uuid.UUID { return uuid.UUID(u) }
+
+// ToGoogleV7 returns a version 7 google/uuid UUID with the timestamp of id.
+// See the package documentation for details.
+func ToGoogleV7(id uuid.UUID) google.UUID { return google.UUID(toV7(id)) }
+
+// FromGoogleV7 returns a UUID with the timestamp of the version 7 UUID u.
+// An error is returned if u is not a version 7 UUID or if its timestamp is outside the
+// range of UUIDs (see uuid.NewFromTime.)
+func FromGoogleV7(u google.UUID) (uuid.UUID, error) { return fromV7(u) }
+
+// ToGofrsV7 returns a version 7 gofrs/uuid UUID with the timestamp of id.
+// See the package documentation for details.
+func ToGofrsV7(id uuid.UUID) gofrs.UUID { return gofrs.UUID(toV7(id)) }
+
+// FromGofrsV7 returns a UUID with the timestamp of the version 7 UUID u.
+// An error is returned if u is not a version 7 UUID or if its timestamp is outside the
+// range of UUIDs (see uuid.NewFromTime.)
+func FromGofrsV7(u gofrs.UUID) (uuid.UUID, error) { return fromV7(u) }
+
+// toV7 returns an RFC 9562 version 7 UUID with the Unix millisecond timestamp of id in
+// bytes 0-5, followed by bytes 6-15 of id with the version and variant bits set
+func toV7(id uuid.UUID) (v7 [16]byte) {
+	t := id.Time()
+	ms := uint64(t.Unix())*1000 + uint64(t.Nanosecond())/1e6
+	v7[0] = byte(ms >> 40)
+	v7[1] = byte(ms >> 32)
+	v7[2] = byte(ms >> 24)
+	v7[3] = byte(ms >> 16)
+	v7[4] = byte(ms >> 8)
+	v7[5] = byte(ms)
+	copy(v7[6:], id[6:])
+	v7[6] = 0x70 | v7[6]&0x0f // version 7
+	v7[8] = 0x80 | v7[8]&0x3f // variant 10
+	return
+}
+
+// fromV7 returns a UUID with the timestamp of the RFC 9562 version 7 UUID v7 and
+// bytes 6-15 of v7 verbatim
+func fromV7(v7 [16]byte) (uuid.UUID, error) {
+	if v7[6]>>4 != 7 || v7[8]>>6 != 2 {
+		return uuid.UUID{}, fmt.Errorf("uuid: not a version 7 UUID")
+	}
+	ms := int64(v7[0])<<40 | int64(v7[1])<<32 | int64(v7[2])<<24 |
+		int64(v7[3])<<16 | int64(v7[4])<<8 | int64(v7[5])
+	return uuid.NewFromTime(time.UnixMilli(ms), v7[6:])
+}
@@ -0,0 +1,87 @@
+package uuidconv
+
+import (
+	"testing"
+	"time"
+
+	gofrs "github.com/gofrs/uuid/v5"
+	google "github.com/google/uuid"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func TestVerbatim(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	g := ToGoogle(id)
+	assert.Eq("ToGoogle bytes", g[:], id[:])
+	assert.Eq("FromGoogle(ToGoogle())", FromGoogle(g), id)
+
+	f := ToGofrs(id)
+	assert.Eq("ToGofrs bytes", f[:], id[:])
+	assert.Eq("FromGofrs(ToGofrs())", FromGofrs(f), id)
+}
+
+func TestV7(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	tm := time.Date(2024, 5, 6, 7, 8, 9, 123*int(time.Millisecond), time.UTC)
+	id := uuid.New(tm.Unix(), tm.Nanosecond(), []byte{0xff, 0xff, 0xff, 3, 4, 5, 6, 7, 8, 9})
+
+	g := ToGoogleV7(id)
+	assert.Eq("google version", g.Version(), google.Version(7))
+	assert.Eq("google variant", g.Variant(), google.RFC4122)
+	sec, nsec := g.Time().UnixTime()
+	assert.Eq("google timestamp", time.Unix(sec, nsec).UTC(), tm)
+	id2, err := FromGoogleV7(g)
+	assert.NoErr("FromGoogleV7", err)
+	assert.Eq("FromGoogleV7 timestamp", id2.Time().UTC(), tm)
+	// all but the version and variant bits are preserved
+	assert.Eq("FromGoogleV7 byte 6", id2[6]&0x0f, id[6]&0x0f)
+	assert.Eq("FromGoogleV7 byte 7", id2[7], id[7])
+	assert.Eq("FromGoogleV7 byte 8", id2[8]&0x3f, id[8]&0x3f)
+	assert.Eq("FromGoogleV7 bytes 9-15", id2[9:], id[9:])
+
+	f := ToGofrsV7(id)
+	assert.Eq("gofrs version", f.Version(), byte(gofrs.V7))
+	assert.Eq("gofrs variant", f.Variant(), byte(gofrs.VariantRFC9562))
+	ts, err := gofrs.TimestampFromV7(f)
+	assert.NoErr("gofrs TimestampFromV7", err)
+	ftm, _ := ts.Time()
+	assert.Eq("gofrs timestamp", ftm.UTC(), tm)
+	id3, err := FromGofrsV7(f)
+	assert.NoErr("FromGofrsV7", err)
+	assert.Eq("FromGofrsV7", id3, id2)
+
+	// v7 UUIDs generated by the libraries themselves
+	g7, _ := google.NewV7()
+	id4, err := FromGoogleV7(g7)
+	assert.NoErr("FromGoogleV7(NewV7())", err)
+	assert.Ok("FromGoogleV7(NewV7()) time", time.Since(id4.Time()) < time.Minute)
+
+	_, err = FromGoogleV7(google.New()) // v4
+	assert.Err("FromGoogleV7 v4", "not a version 7 UUID", err)
+
+	// timestamps outside the range of UUIDs
+	var before, after [16]byte
+	setV7Time(&before, time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC))
+	setV7Time(&after, time.Date(2200, 1, 2, 3, 4, 5, 0, time.UTC))
+	_, err = FromGoogleV7(google.UUID(before))
+	assert.Err("FromGoogleV7 before epoch", "uuid: time 2019-01-02T03:04:05Z out of range", err)
+	_, err = FromGofrsV7(gofrs.UUID(before))
+	assert.Err("FromGofrsV7 before epoch", "out of range", err)
+	_, err = FromGoogleV7(google.UUID(after))
+	assert.Err("FromGoogleV7 after 2156", "uuid: time 2200-01-02T03:04:05Z out of range", err)
+	_, err = FromGofrsV7(gofrs.UUID(after))
+	assert.Err("FromGofrsV7 after 2156", "out of range", err)
+}
+
+// setV7Time makes b a version 7 UUID with the timestamp t
+func setV7Time(b *[16]byte, t time.Time) {
+	ms := t.UnixMilli()
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	b[6] = 0x70
+	b[8] = 0x80
+}
@@ -0,0 +1,20 @@
+module github.com/rsms/go-uuid/uuidgql
+
+go 1.26.0
+
+require (
+	github.com/99designs/gqlgen v0.17.95
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/sosodev/duration v1.4.0 // indirect
+	github.com/vektah/gqlparser/v2 v2.5.37 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
@@ -0,0 +1,58 @@
+// Package uuidgql lets github.com/rsms/go-uuid UUIDs be used as a custom scalar in
+// GraphQL schemas served with github.com/99designs/gqlgen.
+//
+// UUIDs are represented as GraphQL strings in the base62 form returned by uuid.UUID.String.
+// Declare the scalar in your schema with "scalar UUID" and bind it in gqlgen.yml:
+//
+//	models:
+//	  UUID:
+//	    model: github.com/rsms/go-uuid/uuidgql.UUID
+//
+// gqlgen then uses uuid.UUID for the scalar in generated models, converting values with
+// the MarshalUUID and UnmarshalUUID functions of this package. Alternatively, bind the
+// scalar to uuidgql.Value, a wrapper type implementing graphql.Marshaler and
+// graphql.Unmarshaler.
+package uuidgql
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/rsms/go-uuid"
+)
+
+// Value wraps uuid.UUID to implement graphql.Marshaler and graphql.Unmarshaler
+type Value uuid.UUID
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (u Value) MarshalGQL(w io.Writer) {
+	var buf [uuid.StringMaxLen + 2]byte
+	n := uuid.UUID(u).EncodeString(buf[1 : len(buf)-1])
+	buf[n] = '"'
+	buf[len(buf)-1] = '"'
+	w.Write(buf[n:])
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface
+func (u *Value) UnmarshalGQL(v any) error {
+	id, err := UnmarshalUUID(v)
+	*u = Value(id)
+	return err
+}
+
+// MarshalUUID returns a graphql.Marshaler for id.
+// This is used by gqlgen when the UUID scalar is bound to uuid.UUID.
+func MarshalUUID(id uuid.UUID) graphql.Marshaler {
+	return Value(id)
+}
+
+// UnmarshalUUID decodes a UUID from a GraphQL input value, which must be a string.
+// This is used by gqlgen when the UUID scalar is bound to uuid.UUID.
+func UnmarshalUUID(v any) (uuid.UUID, error) {
+	s, ok := v.(string)
+	if !ok {
+		return uuid.UUID{}, fmt.Errorf("uuid: expected a string, got %T", v)
+	}
+	return uuid.Parse(s)
+}
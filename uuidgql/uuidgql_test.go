@@ -0,0 +1,41 @@
+package uuidgql
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func TestMarshal(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	for _, id := range []uuid.UUID{uuid.Min, uuid.Max, uuid.MustGen()} {
+		var buf bytes.Buffer
+		MarshalUUID(id).MarshalGQL(&buf)
+		assert.Eq("MarshalGQL", buf.String(), `"`+id.String()+`"`)
+
+		id2, err := UnmarshalUUID(id.String())
+		assert.NoErr("UnmarshalUUID", err)
+		assert.Eq("UnmarshalUUID", id2, id)
+
+		var v Value
+		assert.NoErr("UnmarshalGQL", v.UnmarshalGQL(id.String()))
+		assert.Eq("UnmarshalGQL", uuid.UUID(v), id)
+	}
+	var _ graphql.Marshaler = Value{}
+	var _ graphql.Unmarshaler = (*Value)(nil)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	_, err := UnmarshalUUID(123)
+	assert.Err("non-string", "expected a string, got int", err)
+	_, err = UnmarshalUUID("abc-def")
+	assert.Err("bad char", "invalid character '-' at offset 3", err)
+	_, err = UnmarshalUUID("")
+	assert.Err("empty", "invalid string length 0", err)
+	var v Value
+	assert.Err("UnmarshalGQL nil", "expected a string, got <nil>", v.UnmarshalGQL(nil))
+}
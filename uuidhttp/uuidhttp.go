@@ -0,0 +1,52 @@
+// Package uuidhttp provides net/http middleware which assigns a UUID to every request.
+//
+//	handler := uuidhttp.Middleware(mux)
+//
+// Handlers retrieve the ID of the request they are serving with FromContext:
+//
+//	func(w http.ResponseWriter, r *http.Request) {
+//		id, _ := uuidhttp.FromContext(r.Context())
+//		slog.InfoContext(r.Context(), "hello", "request_id", id)
+//	}
+package uuidhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rsms/go-uuid"
+)
+
+// Header is the name of the HTTP header carrying request IDs
+const Header = "X-Request-ID"
+
+// Middleware returns a handler which assigns an ID to each request before calling next.
+//
+// If the request has a Header holding a valid, non-zero UUID in its string form (see
+// uuid.Parse), for example set by a proxy or calling service, that ID is used. Otherwise
+// a new UUID is generated with uuid.Gen. The ID is stored in the request context with
+// uuid.NewContext, where FromContext retrieves it, and set as the request and response Header.
+//
+// If generating a UUID fails, the request is answered with status 500 and next is not
+// called.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.Header.Get(Header))
+		if err != nil || id == (uuid.UUID{}) {
+			if id, err = uuid.Gen(); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+		s := id.String()
+		r.Header.Set(Header, s)
+		w.Header().Set(Header, s)
+		next.ServeHTTP(w, r.WithContext(uuid.NewContext(r.Context(), id)))
+	})
+}
+
+// FromContext returns the request ID stored in ctx by Middleware.
+// It is the same as uuid.FromContext.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	return uuid.FromContext(ctx)
+}
@@ -0,0 +1,57 @@
+package uuidhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+)
+
+func serve(r *http.Request) (*httptest.ResponseRecorder, uuid.UUID, string) {
+	var id uuid.UUID
+	var header string
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ = FromContext(r.Context())
+		header = r.Header.Get(Header)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w, id, header
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	w, id, header := serve(httptest.NewRequest("GET", "/", nil))
+	assert.Ok("generated", id != uuid.UUID{})
+	assert.Eq("request header", header, id.String())
+	assert.Eq("response header", w.Header().Get(Header), id.String())
+
+	w, id2, _ := serve(httptest.NewRequest("GET", "/", nil))
+	assert.Ok("unique", id2 != id)
+
+	// valid inbound IDs are kept
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(Header, id.String())
+	w, id2, _ = serve(r)
+	assert.Eq("inbound", id2, id)
+	assert.Eq("inbound response header", w.Header().Get(Header), id.String())
+
+	// invalid inbound IDs are replaced
+	for _, v := range []string{"not-an-id", "0", "0000000000000000000000000"} {
+		r = httptest.NewRequest("GET", "/", nil)
+		r.Header.Set(Header, v)
+		w, id2, header = serve(r)
+		assert.Ok("invalid inbound "+v, id2 != uuid.UUID{} && header == id2.String())
+		assert.Eq("invalid inbound response header", w.Header().Get(Header), id2.String())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	_, ok := FromContext(context.Background())
+	assert.Ok("no ID", !ok)
+}
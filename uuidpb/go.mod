@@ -0,0 +1,16 @@
+module github.com/rsms/go-uuid/uuidpb
+
+go 1.23.0
+
+require (
+	github.com/rsms/go-testutil v0.1.1
+	github.com/rsms/go-uuid v0.0.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+)
+
+replace github.com/rsms/go-uuid => ../
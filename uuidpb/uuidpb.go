@@ -0,0 +1,48 @@
+// Package uuidpb provides a protobuf message for github.com/rsms/go-uuid UUIDs,
+// and helpers for moving UUIDs in and out of protobuf messages.
+//
+// Messages can either embed the rsms.uuid.UUID message defined in uuid.proto or use a plain
+// `bytes` field, in which case FromBytes and ToBytes can be used for conversion.
+package uuidpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative uuid.proto
+
+import (
+	"errors"
+
+	"github.com/rsms/go-uuid"
+)
+
+// ErrMissing is returned when converting a nil message or an unset bytes field to a UUID
+var ErrMissing = errors.New("uuid: missing UUID value")
+
+// New returns a protobuf message for id
+func New(id uuid.UUID) *UUID {
+	return &UUID{Value: ToBytes(id)}
+}
+
+// ToUUID returns the UUID of the message x.
+// An error is returned if x is nil or its value isn't exactly 16 bytes long.
+func (x *UUID) ToUUID() (uuid.UUID, error) {
+	if x == nil {
+		return uuid.UUID{}, ErrMissing
+	}
+	return FromBytes(x.Value)
+}
+
+// ToBytes returns the value of id suitable for a protobuf `bytes` field
+func ToBytes(id uuid.UUID) []byte {
+	b, _ := id.MarshalBinary()
+	return b
+}
+
+// FromBytes returns the UUID of a protobuf `bytes` field.
+// An error is returned if b is empty (unset) or isn't exactly 16 bytes long.
+func FromBytes(b []byte) (uuid.UUID, error) {
+	var id uuid.UUID
+	if len(b) == 0 {
+		return id, ErrMissing
+	}
+	err := id.UnmarshalBinary(b)
+	return id, err
+}
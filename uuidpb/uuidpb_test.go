@@ -0,0 +1,46 @@
+package uuidpb
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+	"github.com/rsms/go-uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUUIDMessage(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	data, err := proto.Marshal(New(id))
+	assert.NoErr("proto.Marshal", err)
+
+	var m UUID
+	assert.NoErr("proto.Unmarshal", proto.Unmarshal(data, &m))
+	id2, err := m.ToUUID()
+	assert.NoErr("ToUUID", err)
+	assert.Eq("round trip", id2, id)
+
+	var nilmsg *UUID
+	_, err = nilmsg.ToUUID()
+	assert.Eq("nil message", err, ErrMissing)
+	_, err = (&UUID{}).ToUUID()
+	assert.Eq("unset value", err, ErrMissing)
+	_, err = (&UUID{Value: []byte{1, 2, 3}}).ToUUID()
+	assert.Err("short value", "invalid binary data length 3", err)
+}
+
+func TestBytes(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id := uuid.MustGen()
+
+	b := ToBytes(id)
+	assert.Eq("ToBytes", b, id[:])
+	id2, err := FromBytes(b)
+	assert.NoErr("FromBytes", err)
+	assert.Eq("FromBytes(ToBytes())", id2, id)
+	_, err = FromBytes(nil)
+	assert.Eq("FromBytes(nil)", err, ErrMissing)
+	_, err = FromBytes(make([]byte, 17))
+	assert.Err("FromBytes long", "invalid binary data length 17", err)
+}
@@ -0,0 +1,119 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// GenWithPrefix generates a UUID whose string representation (see String) starts with
+// prefix, e.g. "DEMO", for demos, documentation and tests which want recognizable IDs.
+//
+// The leading characters of the string form encode the timestamp, so rather than
+// searching for a match among random UUIDs (which would never end) GenWithPrefix picks the
+// valid timestamp closest to the current time for which some UUIDs have a string starting
+// with prefix, and then a random UUID among those. The result thus has a valid layout, with
+// a millisecond part of 0-999, but its time is generally not the current time unless prefix
+// is a prefix of the strings of current UUIDs.
+//
+// An error is returned if prefix is empty, contains characters outside of the base62
+// alphabet or no UUID with a valid timestamp has a string starting with prefix, like
+// prefixes of 22 characters which sort after "7n42DEicV99LssyYfFh1bD", the string of the
+// greatest UUID with a valid timestamp.
+func GenWithPrefix(prefix string) (UUID, error) {
+	if len(prefix) == 0 || len(prefix) > StringMaxLen {
+		return UUID{}, fmt.Errorf("uuid: invalid prefix length %d", len(prefix))
+	}
+	for i := 0; i < len(prefix); i++ {
+		if !isBase62(prefix[i]) {
+			return UUID{}, &InvalidCharError{Char: prefix[i], Offset: i}
+		}
+	}
+	if prefix[0] == '0' { // only Min has a string starting with '0'
+		if prefix == "0" {
+			return Min, nil
+		}
+		return UUID{}, fmt.Errorf("uuid: no UUID has a string starting with %q", prefix)
+	}
+
+	// timestamps are the 48 most significant bits of UUIDs
+	const randomBits = 80
+	now := genTime(time.Now(), idEpochBase)
+	nowT := new(big.Int).SetBytes(now[:6]).Uint64()
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	base := big.NewInt(62)
+	p := new(big.Int)
+	for i := 0; i < len(prefix); i++ {
+		p.Mul(p, base).Add(p, big.NewInt(int64(base62Value(prefix[i]))))
+	}
+
+	// Strings of length n starting with prefix encode the values in [lo, hi) where
+	// lo = prefix * 62^(n-len(prefix)) and hi = (prefix+1) * 62^(n-len(prefix)).
+	// Find the n and valid timestamp within the range closest to the current time.
+	var bestLo, bestHi *big.Int
+	var bestT, bestDist uint64
+	lo, hi := new(big.Int).Set(p), new(big.Int).Add(p, big.NewInt(1))
+	for n := len(prefix); n <= StringMaxLen && lo.Cmp(limit) < 0; n++ {
+		h := hi
+		if h.Cmp(limit) > 0 {
+			h = limit
+		}
+		tlo := new(big.Int).Rsh(lo, randomBits).Uint64()
+		thi := new(big.Int).Rsh(new(big.Int).Sub(h, big.NewInt(1)), randomBits).Uint64()
+		if t, ok := nearestTimestamp(nowT, tlo, thi); ok {
+			dist := max(t, nowT) - min(t, nowT)
+			if bestLo == nil || dist < bestDist {
+				bestLo, bestHi = new(big.Int).Set(lo), new(big.Int).Set(h)
+				bestT, bestDist = t, dist
+			}
+		}
+		lo.Mul(lo, base)
+		hi.Mul(hi, base)
+	}
+	if bestLo == nil {
+		return UUID{}, fmt.Errorf("uuid: no UUID has a string starting with %q", prefix)
+	}
+
+	// pick a random value among those of the range with the timestamp bestT
+	tlo := new(big.Int).Lsh(new(big.Int).SetUint64(bestT), randomBits)
+	thi := new(big.Int).Lsh(new(big.Int).SetUint64(bestT+1), randomBits)
+	if tlo.Cmp(bestLo) < 0 {
+		tlo = bestLo
+	}
+	if thi.Cmp(bestHi) > 0 {
+		thi = bestHi
+	}
+	v, err := rand.Int(rand.Reader, new(big.Int).Sub(thi, tlo))
+	if err != nil {
+		return UUID{}, err
+	}
+	var id UUID
+	v.Add(v, tlo).FillBytes(id[:])
+	return id, nil
+}
+
+// nearestTimestamp returns the timestamp (bytes 0-5 of a UUID as an integer) in [lo, hi]
+// closest to t which has a valid millisecond part, i.e. 0-999
+func nearestTimestamp(t, lo, hi uint64) (uint64, bool) {
+	c := min(max(t, lo), hi)
+	if c&0xffff <= 999 {
+		return c, true
+	}
+	down := c&^0xffff | 999   // last millisecond of the same second
+	up := c&^0xffff + 0x10000 // first millisecond of the next second
+	okDown, okUp := down >= lo, up <= hi
+	if okDown && okUp {
+		if t-down <= up-t {
+			return down, true
+		}
+		return up, true
+	}
+	if okDown {
+		return down, true
+	}
+	if okUp {
+		return up, true
+	}
+	return 0, false
+}
@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestGenWithPrefix(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	now := MustGen().String()
+	for _, prefix := range []string{"DEMO", "A", "z", "7", "Test", now[:3], now[:12], lastValid, "0"} {
+		for i := 0; i < 20; i++ {
+			id, err := GenWithPrefix(prefix)
+			assert.NoErr("GenWithPrefix "+prefix, err)
+			assert.Ok("GenWithPrefix "+prefix+" "+id.String(), strings.HasPrefix(id.String(), prefix))
+			_, ms := id.Timestamp()
+			assert.Ok("GenWithPrefix "+prefix+" valid millisecond", ms <= 999)
+		}
+	}
+
+	// the current time when the prefix allows it
+	for _, n := range []int{1, 3, 8} {
+		id, err := GenWithPrefix(MustGen().String()[:n])
+		assert.NoErr("GenWithPrefix", err)
+		assert.Ok("current time %v", time.Since(id.Time()).Abs() < 50*time.Millisecond, id.Time())
+	}
+	id, err := GenWithPrefix(now[:3])
+	assert.NoErr("GenWithPrefix", err)
+	assert.Eq("same length as current UUIDs", len(id.String()), len(now))
+
+	assert.Eq("last valid", must(GenWithPrefix(lastValid)).String(), lastValid)
+	assert.Eq("Min", must(GenWithPrefix("0")), Min)
+
+	for _, prefix := range []string{
+		"", "00", "01", maxString, "7n42DEicV99LssyYfFh1bE", "8" + maxString[1:],
+		strings.Repeat("1", 23),
+	} {
+		_, err := GenWithPrefix(prefix)
+		assert.Ok("unattainable "+prefix, err != nil)
+	}
+	_, err = GenWithPrefix("8" + maxString[1:])
+	assert.Err("unattainable", `no UUID has a string starting with "8n42`, err)
+	var cerr *InvalidCharError
+	_, err = GenWithPrefix("DEMO-")
+	assert.Ok("InvalidCharError", errors.As(err, &cerr) && cerr.Offset == 4)
+}
+
+// lastValid is the string of the greatest UUID with a valid timestamp
+const lastValid = "7n42DEicV99LssyYfFh1bD"
+
+func TestNearestTimestamp(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	sec := uint64(5) << 16
+	for _, c := range []struct {
+		t, lo, hi, want uint64
+		ok              bool
+	}{
+		{sec | 10, 0, 1 << 48, sec | 10, true},
+		{sec | 990, sec | 995, 1 << 48, sec | 995, true},
+		{sec | 1000, 0, 1 << 48, sec | 999, true},
+		{sec | 0xfff0, 0, 1 << 48, sec + 0x10000, true},
+		{sec | 1000, sec | 1000, 1 << 48, sec + 0x10000, true},
+		{sec | 1000, 0, sec | 1001, sec | 999, true},
+		{0, sec | 1000, sec | 0xffff, 0, false},
+	} {
+		got, ok := nearestTimestamp(c.t, c.lo, c.hi)
+		assert.Ok("nearestTimestamp(%x, %x, %x) = %x, %v", got == c.want && ok == c.ok,
+			c.t, c.lo, c.hi, got, ok)
+	}
+}
+
+func must(id UUID, err error) UUID {
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
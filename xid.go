@@ -0,0 +1,22 @@
+package uuid
+
+// FromXID returns a UUID for the github.com/rs/xid ID x, which can be passed as a
+// [12]byte(x) conversion.
+//
+// xids are 12 bytes: a 4-byte Unix timestamp in seconds followed by a 3-byte machine ID,
+// a 2-byte process ID and a 3-byte counter. The UUID gets the same timestamp (with a zero
+// millisecond part) and the 8 bytes following the timestamp are stored in bytes 6-13, with
+// bytes 14-15 set to zero. The mapping is deterministic and maintains the order of xids.
+// An error is returned if the timestamp of x is before the UUID epoch (2020-09-13.)
+func FromXID(x [12]byte) (UUID, error) {
+	return fromSecondID(x, "xid")
+}
+
+// ToXID returns an xid with the timestamp of id (see FromXID.)
+// The millisecond part of the timestamp and bytes 14-15 of id are lost, while
+// ToXID(FromXID(x)) == x.
+// An error is returned if the timestamp of id is after 2106-02-07 06:28:15 UTC, the last
+// time representable by an xid.
+func (id UUID) ToXID() ([12]byte, error) {
+	return id.toSecondID("xid")
+}
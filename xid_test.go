@@ -0,0 +1,35 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestXID(t *testing.T) {
+	assert := testutil.NewAssert(t)
+
+	// 653a0c9b = 2023-10-26 06:52:11 UTC, machine 2fc4a5, pid 1c3e, counter 0a0b0c
+	x := [12]byte{0x65, 0x3a, 0x0c, 0x9b, 0x2f, 0xc4, 0xa5, 0x1c, 0x3e, 0x0a, 0x0b, 0x0c}
+	id, err := FromXID(x)
+	assert.NoErr("FromXID", err)
+	assert.Eq("FromXID time", id.Time().UTC(), time.Unix(0x653a0c9b, 0).UTC())
+	assert.Eq("FromXID bytes", id[6:], []byte{0x2f, 0xc4, 0xa5, 0x1c, 0x3e, 0x0a, 0x0b, 0x0c, 0, 0})
+
+	x2, err := id.ToXID()
+	assert.NoErr("ToXID", err)
+	assert.Eq("ToXID(FromXID())", x2, x)
+
+	// order is maintained
+	x3 := x
+	x3[11]++
+	id3, _ := FromXID(x3)
+	assert.Ok("order", bytes.Compare(id[:], id3[:]) < 0)
+
+	_, err = FromXID([12]byte{})
+	assert.Err("FromXID before epoch", "xid timestamp 0 out of range", err)
+	_, err = Max.ToXID()
+	assert.Err("ToXID after 2106", "out of range for xid", err)
+}
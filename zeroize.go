@@ -0,0 +1,20 @@
+package uuid
+
+import "runtime"
+
+// Zeroize overwrites the UUID with zeros, for UUIDs which double as capability tokens (see
+// GenOpaque) and shouldn't linger in memory, e.g. in heap dumps, once used.
+//
+// Only the memory of the receiver is wiped. Since UUIDs are values, copies made by
+// assignment or by passing them around, as well as strings made with String(), are not
+// affected; keep secret UUIDs in one place and pass pointers to avoid copies.
+func (id *UUID) Zeroize() {
+	clear(id[:])
+	runtime.KeepAlive(id)
+}
+
+// ZeroizeAll overwrites every UUID in ids with zeros (see Zeroize)
+func ZeroizeAll(ids []UUID) {
+	clear(ids)
+	runtime.KeepAlive(ids)
+}
@@ -0,0 +1,21 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/rsms/go-testutil"
+)
+
+func TestZeroize(t *testing.T) {
+	assert := testutil.NewAssert(t)
+	id, err := GenOpaque()
+	assert.NoErr("GenOpaque", err)
+	id.Zeroize()
+	assert.Eq("Zeroize", id, UUID{})
+
+	ids := []UUID{MustGen(), MustGen(), MustGen()}
+	ZeroizeAll(ids)
+	for _, id := range ids {
+		assert.Eq("ZeroizeAll", id, UUID{})
+	}
+}